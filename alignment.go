@@ -0,0 +1,26 @@
+package golcs
+
+// AlignmentRows implements LCS.AlignmentRows()
+//
+// The rows are EditScript() laid out in two rows of the same length, one
+// column per edit. An EditEqual column has the element of Left in topRow and
+// the one of Right in bottomRow. An EditDelete has gap in bottomRow and an
+// EditInsert has gap in topRow, so removing the gaps from topRow gives Left and
+// from bottomRow gives Right, unless gap itself is an element of them.
+func (lcs *lcs) AlignmentRows(gap interface{}) (topRow, bottomRow []interface{}) {
+	edits := lcs.EditScript()
+
+	topRow = make([]interface{}, len(edits))
+	bottomRow = make([]interface{}, len(edits))
+	for i, edit := range edits {
+		switch edit.Kind {
+		case EditEqual:
+			topRow[i], bottomRow[i] = lcs.left[edit.Left], lcs.right[edit.Right]
+		case EditDelete:
+			topRow[i], bottomRow[i] = lcs.left[edit.Left], gap
+		case EditInsert:
+			topRow[i], bottomRow[i] = gap, lcs.right[edit.Right]
+		}
+	}
+	return topRow, bottomRow
+}
@@ -0,0 +1,50 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlignmentRows(t *testing.T) {
+	cases := []struct {
+		left   []interface{}
+		right  []interface{}
+		top    []interface{}
+		bottom []interface{}
+	}{
+		{
+			left:   []interface{}{"A", "C", "G", "T"},
+			right:  []interface{}{"A", "G", "G", "T", "C"},
+			top:    []interface{}{"A", "C", "-", "G", "T", "-"},
+			bottom: []interface{}{"A", "-", "G", "G", "T", "C"},
+		},
+		{
+			left:   []interface{}{1, 2, 3},
+			right:  []interface{}{4, 2, 5, 6},
+			top:    []interface{}{1, "-", 2, 3, "-", "-"},
+			bottom: []interface{}{"-", 4, 2, "-", 5, 6},
+		},
+		{
+			left:   []interface{}{},
+			right:  []interface{}{1},
+			top:    []interface{}{"-"},
+			bottom: []interface{}{1},
+		},
+		{
+			left:   []interface{}{},
+			right:  []interface{}{},
+			top:    []interface{}{},
+			bottom: []interface{}{},
+		},
+	}
+
+	for i, c := range cases {
+		top, bottom := New(c.left, c.right).AlignmentRows("-")
+		if !reflect.DeepEqual(top, c.top) {
+			t.Errorf("test case %d failed at top row, actual: %v, expected: %v", i, top, c.top)
+		}
+		if !reflect.DeepEqual(bottom, c.bottom) {
+			t.Errorf("test case %d failed at bottom row, actual: %v, expected: %v", i, bottom, c.bottom)
+		}
+	}
+}
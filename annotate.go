@@ -0,0 +1,48 @@
+package golcs
+
+import "context"
+
+// AnnotatedPair is an IndexPair with the number of its neighbors which match as well.
+type AnnotatedPair struct {
+	IndexPair
+	// NeighborMatches is 0, 1 or 2, the number of the diagonal neighbors matching.
+	NeighborMatches int
+}
+
+// AnnotatedPairs implements LCS.AnnotatedPairs()
+func (lcs *lcs) AnnotatedPairs() []AnnotatedPair {
+	pairs, _ := lcs.AnnotatedPairsContext(context.Background())
+	return pairs
+}
+
+// AnnotatedPairsContext implements LCS.AnnotatedPairsContext()
+//
+// The neighbors of a pair of Left[x] and Right[y] are the elements on the
+// same diagonal, Left[x-1] and Right[y-1] before it and Left[x+1] and
+// Right[y+1] after it, and NeighborMatches counts those which match with the
+// equality, whether they are in IndexPairs() or not; a neighbor out of the
+// arrays never matches. A match in the middle of a run of matches has 2, one
+// at an end of a run has 1 and an isolated one, which is more likely to be a
+// coincidence, has 0.
+func (lcs *lcs) AnnotatedPairsContext(ctx context.Context) ([]AnnotatedPair, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	annotated := make([]AnnotatedPair, len(pairs))
+	for i, pair := range pairs {
+		neighbors := 0
+		if pair.Left > 0 && pair.Right > 0 && lcs.match(pair.Left-1, pair.Right-1) {
+			neighbors++
+		}
+		if pair.Left+1 < len(lcs.left) && pair.Right+1 < len(lcs.right) && lcs.match(pair.Left+1, pair.Right+1) {
+			neighbors++
+		}
+		annotated[i] = AnnotatedPair{IndexPair: pair, NeighborMatches: neighbors}
+	}
+	if err := lcs.failed(); err != nil {
+		return nil, err
+	}
+	return annotated, nil
+}
@@ -0,0 +1,46 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnnotatedPairs(t *testing.T) {
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected []AnnotatedPair
+	}{
+		{
+			left:     []interface{}{},
+			right:    []interface{}{"a"},
+			expected: []AnnotatedPair{},
+		},
+		{
+			left:  []interface{}{"a", "b", "c", "x", "d"},
+			right: []interface{}{"a", "b", "c", "y", "d"},
+			expected: []AnnotatedPair{
+				{IndexPair{0, 0}, 1},
+				{IndexPair{1, 1}, 2},
+				{IndexPair{2, 2}, 1},
+				{IndexPair{4, 4}, 0},
+			},
+		},
+		// a neighbor matching counts without being in the pairs
+		{
+			left:  []interface{}{"a", "b", "b"},
+			right: []interface{}{"b", "a", "b"},
+			expected: []AnnotatedPair{
+				{IndexPair{0, 1}, 1},
+				{IndexPair{2, 2}, 0},
+			},
+		},
+	}
+
+	for i, cs := range cases {
+		actual := New(cs.left, cs.right).AnnotatedPairs()
+		if !reflect.DeepEqual(actual, cs.expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, cs.expected)
+		}
+	}
+}
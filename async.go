@@ -0,0 +1,71 @@
+package golcs
+
+import (
+	"context"
+	"errors"
+)
+
+// WithAsyncEqual sets a function to compare elements by a remote service,
+// such as a model of the semantic similarity of texts, whose latency calls
+// for many comparisons in flight at the same time.
+//
+// The DP needs the result of every pair of elements, m*n calls for a Left of
+// size m and a Right of size n, so all of them are made up front as a batch:
+// each row of the memo table, the comparisons of one element of Right with
+// every element of Left, is a task, and up to concurrency tasks run at the
+// same time, so concurrency bounds the calls in flight. The calculation then
+// reads the results. The context of the context aware method is given to
+// equal, and its cancellation stops the batch; use the context aware methods
+// with this option, as an error of equal is only returned by them, like
+// WithEqualErr(). The methods without a context make the batch with
+// context.Background(). concurrency less than 1 is treated as 1.
+func WithAsyncEqual(equal func(ctx context.Context, a, b interface{}) (bool, error), concurrency int) Option {
+	return func(lcs *lcs) {
+		lcs.asyncEqual = equal
+		lcs.asyncConcurrency = concurrency
+		// for the comparisons outside the DP, one by one
+		lcs.equal = func(a, b interface{}) bool {
+			same, err := equal(context.Background(), a, b)
+			if err != nil {
+				lcs.fail(err)
+				return false
+			}
+			return same
+		}
+		lcs.customEqual = true
+	}
+}
+
+// compareAll makes every comparison of WithAsyncEqual() as a batch unless
+// it is done already.
+func (lcs *lcs) compareAll(ctx context.Context) error {
+	if lcs.asyncEqual == nil || lcs.asyncMatches != nil {
+		return lcs.failed()
+	}
+	if err := lcs.failed(); err != nil {
+		return err
+	}
+
+	sizeX := len(lcs.left)
+	matches := make([]bool, sizeX*len(lcs.right))
+	err := batch(ctx, len(lcs.right), lcs.asyncConcurrency, func(ctx context.Context, y int) error {
+		for x := 0; x < sizeX; x++ {
+			same, err := lcs.asyncEqual(ctx, lcs.leftKeys[x], lcs.rightKeys[y])
+			if err != nil {
+				return err
+			}
+			matches[y*sizeX+x] = same
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() == nil || !errors.Is(err, ctx.Err()) {
+			// a failure of the equality, which a retry would not fix, unlike
+			// the cancellation even when equal wraps it
+			lcs.fail(err)
+		}
+		return err
+	}
+	lcs.asyncMatches = matches
+	return nil
+}
@@ -0,0 +1,114 @@
+package golcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// remoteStub is a comparator with the latency of a remote call.
+type remoteStub struct {
+	lock     sync.Mutex
+	inFlight int
+	peak     int
+	calls    int
+	fail     interface{}
+}
+
+func (stub *remoteStub) equal(ctx context.Context, a, b interface{}) (bool, error) {
+	stub.lock.Lock()
+	stub.inFlight++
+	stub.calls++
+	stub.peak = max(stub.peak, stub.inFlight)
+	stub.lock.Unlock()
+	defer func() {
+		stub.lock.Lock()
+		stub.inFlight--
+		stub.lock.Unlock()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(time.Millisecond):
+	}
+	if stub.fail != nil && (a == stub.fail || b == stub.fail) {
+		return false, errors.New("remote failure")
+	}
+	return a == b, nil
+}
+
+func TestWithAsyncEqual(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 20, 4)
+	right := randomInts(random, 30, 4)
+
+	stub := &remoteStub{}
+	newLcs := New(left, right, WithAsyncEqual(stub.equal, 8))
+	pairs, err := newLcs.IndexPairsContext(context.Background())
+	if expected := New(left, right).IndexPairs(); err != nil || !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("failed at index pairs, actual: %v, %v, expected: %v", pairs, err, expected)
+	}
+	if length, err := newLcs.LengthContext(context.Background()); err != nil || length != len(pairs) {
+		t.Errorf("failed at length, actual: %v, %v, expected: %v", length, err, len(pairs))
+	}
+	// every pair is compared once for all the methods
+	if expected := len(left) * len(right); stub.calls != expected {
+		t.Errorf("failed at calls, actual: %d, expected: %d", stub.calls, expected)
+	}
+	if stub.peak > 8 || stub.peak < 2 {
+		t.Errorf("failed at concurrency, actual peak: %d", stub.peak)
+	}
+}
+
+func TestWithAsyncEqualError(t *testing.T) {
+	left := []interface{}{1, 2, 3}
+	right := []interface{}{1, 4, 3}
+
+	newLcs := New(left, right, WithAsyncEqual((&remoteStub{fail: 4}).equal, 2))
+	if _, err := newLcs.IndexPairsContext(context.Background()); err == nil {
+		t.Errorf("failed at index pairs, the error is not returned")
+	}
+	if _, err := newLcs.TableContext(context.Background()); err == nil {
+		t.Errorf("failed at table, the error is not kept")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	newLcs = New(left, right, WithAsyncEqual((&remoteStub{}).equal, 2))
+	if _, err := newLcs.LengthContext(ctx); err != context.Canceled {
+		t.Errorf("failed at canceled length, actual: %v, expected: %v", err, context.Canceled)
+	}
+	// a cancellation can be retried
+	if length, err := newLcs.LengthContext(context.Background()); err != nil || length != 2 {
+		t.Errorf("failed at retried length, actual: %v, %v, expected: %v", length, err, 2)
+	}
+}
+
+func TestWithAsyncEqualWrappedCancel(t *testing.T) {
+	left := []interface{}{1, 2, 3}
+	right := []interface{}{1, 4, 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	// the first call is canceled in flight and wraps the error of ctx
+	wrapping := func(callCtx context.Context, a, b interface{}) (bool, error) {
+		cancel()
+		if err := callCtx.Err(); err != nil {
+			return false, fmt.Errorf("remote call: %w", err)
+		}
+		return a == b, nil
+	}
+
+	newLcs := New(left, right, WithAsyncEqual(wrapping, 2))
+	if _, err := newLcs.LengthContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("failed at canceled length, actual: %v, expected: %v", err, context.Canceled)
+	}
+	// the wrapped cancellation is not kept as a failure
+	if length, err := newLcs.LengthContext(context.Background()); err != nil || length != 2 {
+		t.Errorf("failed at retried length, actual: %v, %v, expected: %v", length, err, 2)
+	}
+}
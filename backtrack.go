@@ -0,0 +1,48 @@
+package golcs
+
+import "context"
+
+// BacktrackPath implements LCS.BacktrackPath()
+func (lcs *lcs) BacktrackPath() []IndexPair {
+	path, _ := lcs.BacktrackPathContext(context.Background())
+	return path
+}
+
+// BacktrackPathContext implements LCS.BacktrackPathContext()
+//
+// The path is the cells of Table() visited by backtracking it for
+// IndexPairs(), in the order visited, from the bottom-right corner
+// {len(Left), len(Right)} to the top-left one {0, 0}. The coordinates are the
+// indices of the table, not of the elements: a cell {x, y} is the LCS of
+// Left[:x] and Right[:y], and a step from {x, y} to {x-1, y-1} is the match of
+// the pair {x-1, y-1} of IndexPairs(). The other steps decrement only x, for
+// an element of Left not in the LCS, or only y, for one of Right, and once x
+// or y is 0, the path goes along the edge of the table to the corner. The
+// ties break as for IndexPairs() without the options choosing the pairs in
+// their own way, like WithPatience(), which do not backtrack the memo table.
+func (lcs *lcs) BacktrackPathContext(ctx context.Context) ([]IndexPair, error) {
+	table, err := lcs.TableContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y := len(lcs.left), len(lcs.right)
+	path := make([]IndexPair, 0, x+y+1)
+	path = append(path, IndexPair{Left: x, Right: y})
+	for x > 0 || y > 0 {
+		if x == 0 {
+			y--
+		} else if y == 0 {
+			x--
+		} else if lcs.match(x-1, y-1) {
+			x--
+			y--
+		} else if table[x-1][y] >= table[x][y-1] {
+			x--
+		} else {
+			y--
+		}
+		path = append(path, IndexPair{Left: x, Right: y})
+	}
+	return path, nil
+}
@@ -0,0 +1,74 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBacktrackPath(t *testing.T) {
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected []IndexPair
+	}{
+		{
+			left:     []interface{}{"a", "b"},
+			right:    []interface{}{"b"},
+			expected: []IndexPair{{2, 1}, {1, 0}, {0, 0}},
+		},
+		{
+			left:     []interface{}{"a", "b", "c"},
+			right:    []interface{}{"a", "c", "d"},
+			expected: []IndexPair{{3, 3}, {3, 2}, {2, 1}, {1, 1}, {0, 0}},
+		},
+		{
+			left:     []interface{}{},
+			right:    []interface{}{"a", "b"},
+			expected: []IndexPair{{0, 2}, {0, 1}, {0, 0}},
+		},
+		{
+			left:     []interface{}{},
+			right:    []interface{}{},
+			expected: []IndexPair{{0, 0}},
+		},
+	}
+
+	for i, c := range cases {
+		if actual := New(c.left, c.right).BacktrackPath(); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.expected)
+		}
+	}
+}
+
+func TestBacktrackPathRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+
+		newLcs := New(left, right)
+		path := newLcs.BacktrackPath()
+		if first := path[0]; first != (IndexPair{Left: len(left), Right: len(right)}) {
+			t.Fatalf("test case %d failed at the first cell, actual: %v", i, first)
+		}
+		if last := path[len(path)-1]; last != (IndexPair{}) {
+			t.Fatalf("test case %d failed at the last cell, actual: %v", i, last)
+		}
+
+		// the diagonal steps are the pairs from the end
+		matches := []IndexPair{}
+		for j := 1; j < len(path); j++ {
+			dx, dy := path[j-1].Left-path[j].Left, path[j-1].Right-path[j].Right
+			if dx < 0 || dx > 1 || dy < 0 || dy > 1 || dx+dy == 0 {
+				t.Fatalf("test case %d failed at step %d, actual: %v to %v", i, j, path[j-1], path[j])
+			}
+			if dx == 1 && dy == 1 {
+				matches = append([]IndexPair{path[j]}, matches...)
+			}
+		}
+		if expected := newLcs.IndexPairs(); !reflect.DeepEqual(matches, expected) {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, matches, expected)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package golcs
+
+import (
+	"context"
+	"sync"
+)
+
+// Pair is a pair of arrays to be compared.
+type Pair struct {
+	Left  []interface{}
+	Right []interface{}
+}
+
+// BatchRatioAtLeast reports whether Ratio() of each pair is at least threshold,
+// calculating up to concurrency pairs at the same time.
+//
+// QuickRatio() of each pair is checked first, and the LCS is calculated only
+// when the bound reaches the threshold. As QuickRatio() is never less than
+// Ratio(), the prefilter never rejects a pair which the full calculation
+// accepts, and the results are the same as calling Ratio() for every pair.
+// The options are given to New() for each pair. When ctx is canceled, the
+// whole batch stops and ctx.Err() is returned. concurrency less than 1 is
+// treated as 1.
+func BatchRatioAtLeast(ctx context.Context, pairs []Pair, threshold float64, concurrency int, opts ...Option) ([]bool, error) {
+	results := make([]bool, len(pairs))
+	err := batch(ctx, len(pairs), concurrency, func(ctx context.Context, i int) error {
+		newLcs := New(pairs[i].Left, pairs[i].Right, opts...)
+		if newLcs.QuickRatio() < threshold {
+			return nil
+		}
+		ratio, err := newLcs.RatioContext(ctx)
+		results[i] = ratio >= threshold
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// batch calls task for each of size indices with up to concurrency goroutines
+// and returns the first error.
+func batch(ctx context.Context, size, concurrency int, task func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indices := make(chan int)
+	errs := make(chan error, max(concurrency, 1))
+	wg := sync.WaitGroup{}
+	for w := 0; w < max(concurrency, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if err := task(ctx, i); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < size; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return err
+	}
+	return ctx.Err()
+}
+
+// SimilarityMatrix is SimilarityMatrixContext() without cancellation.
+func SimilarityMatrix(items [][]interface{}, concurrency int, opts ...Option) [][]float64 {
+	matrix, _ := SimilarityMatrixContext(context.Background(), items, concurrency, opts...)
+	return matrix
+}
+
+// SimilarityMatrixContext calculates Ratio() of every pair of the items,
+// calculating up to concurrency rows at the same time.
+//
+// matrix[i][j] is Ratio() of items[i] as Left and items[j] as Right. Ratio()
+// does not depend on the order of the two arrays, so the matrix is symmetric:
+// only the pairs with i < j are calculated and copied to matrix[j][i], and the
+// diagonal is 1.0. Each items[i] is given to Prepare() once for its row, and
+// QuickRatio() of each pair is checked first to skip the LCS for the pairs
+// with nothing in common, whose ratio is 0. The options are given to Prepare().
+// When ctx is canceled, the whole matrix stops and ctx.Err() is returned.
+// concurrency less than 1 is treated as 1.
+func SimilarityMatrixContext(ctx context.Context, items [][]interface{}, concurrency int, opts ...Option) ([][]float64, error) {
+	matrix := make([][]float64, len(items))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(items))
+		matrix[i][i] = 1.0
+	}
+	err := batch(ctx, len(items), concurrency, func(ctx context.Context, i int) error {
+		prepared := Prepare(items[i], opts...)
+		for j := i + 1; j < len(items); j++ {
+			newLcs := prepared.Diff(items[j])
+			if newLcs.QuickRatio() == 0 {
+				continue
+			}
+			ratio, err := newLcs.RatioContext(ctx)
+			if err != nil {
+				return err
+			}
+			matrix[i][j], matrix[j][i] = ratio, ratio
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matrix, nil
+}
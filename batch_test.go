@@ -0,0 +1,70 @@
+package golcs
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBatchRatioAtLeast(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	pairs := make([]Pair, 100)
+	for i := range pairs {
+		pairs[i] = Pair{
+			Left:  randomInts(random, random.Intn(30), 2+i%10),
+			Right: randomInts(random, random.Intn(30), 2+i%10),
+		}
+	}
+
+	for _, threshold := range []float64{0, 0.3, 0.5, 0.7, 1} {
+		for _, concurrency := range []int{0, 1, 4} {
+			results, err := BatchRatioAtLeast(context.Background(), pairs, threshold, concurrency)
+			if err != nil {
+				t.Fatalf("unexpected err: %s", err)
+			}
+			for i, pair := range pairs {
+				expected := New(pair.Left, pair.Right).Ratio() >= threshold
+				if results[i] != expected {
+					t.Errorf("pair %d failed with threshold %f, actual: %t, expected: %t", i, threshold, results[i], expected)
+				}
+			}
+		}
+	}
+}
+
+func TestBatchRatioAtLeastCancel(t *testing.T) {
+	pairs := []Pair{{Left: make([]interface{}, 100), Right: make([]interface{}, 100)}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := BatchRatioAtLeast(ctx, pairs, 0.5, 2); err != context.Canceled {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestSimilarityMatrix(t *testing.T) {
+	items := [][]interface{}{
+		{1, 2, 3, 4},
+		{1, 2, 4},
+		{5, 6},
+		{4, 3, 2, 1},
+	}
+	expected := [][]float64{
+		{1.0, 6.0 / 7.0, 0, 2.0 / 8.0},
+		{6.0 / 7.0, 1.0, 0, 2.0 / 7.0},
+		{0, 0, 1.0, 0},
+		{2.0 / 8.0, 2.0 / 7.0, 0, 1.0},
+	}
+	for _, concurrency := range []int{0, 1, 3} {
+		if actual := SimilarityMatrix(items, concurrency); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("concurrency %d, actual: %v, expected: %v", concurrency, actual, expected)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SimilarityMatrixContext(ctx, items, 2); err != context.Canceled {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
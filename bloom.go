@@ -0,0 +1,113 @@
+package golcs
+
+import "reflect"
+
+// WithBloomPrefilter skips the equality for the elements of Left which are
+// definitely not in Right, which saves the calls of an expensive equality
+// given with WithEqual() when few elements are in common.
+//
+// A bloom filter of the fingerprints of the elements of Right is built once,
+// and each element of Left is looked up in it by its fingerprint. An element
+// not found never matches and is never compared. A false positive of the
+// filter only costs the comparisons: the element falls through to the
+// equality as without the option, so the results are always the same. The
+// elements the equality says are the same must have the same fingerprint.
+// When fingerprint is nil, booleans, numbers and strings are fingerprinted by
+// their values and the other elements always fall through, which is right
+// only for the default equality, so nil disables the option with WithEqual().
+func WithBloomPrefilter(fingerprint func(interface{}) uint64) Option {
+	return func(lcs *lcs) {
+		lcs.bloomPrefilter = true
+		lcs.fingerprint = fingerprint
+	}
+}
+
+// bloomBitsPerElement and bloomHashes give about 1% of false positives.
+const (
+	bloomBitsPerElement = 10
+	bloomHashes         = 7
+)
+
+// filterLeft marks the elements of Left which may be in Right with the
+// bloom filter of WithBloomPrefilter().
+func (lcs *lcs) filterLeft() {
+	lcs.maybeInRight = nil
+	if !lcs.bloomPrefilter || (lcs.fingerprint == nil && lcs.customEqual) {
+		return
+	}
+
+	bits := make([]uint64, (len(lcs.rightKeys)*bloomBitsPerElement+63)/64+1)
+	size := uint64(len(bits) * 64)
+	// whether Right has elements without fingerprints
+	others := false
+	for _, key := range lcs.rightKeys {
+		hash1, hash2, ok := lcs.bloomHash(key)
+		if !ok {
+			others = true
+			continue
+		}
+		for i := uint64(0); i < bloomHashes; i++ {
+			bit := (hash1 + i*hash2) % size
+			bits[bit/64] |= 1 << (bit % 64)
+		}
+	}
+
+	lcs.maybeInRight = make([]bool, len(lcs.leftKeys))
+	for x, key := range lcs.leftKeys {
+		hash1, hash2, ok := lcs.bloomHash(key)
+		if !ok {
+			// an element without a fingerprint is not a basic value, so it
+			// may only be the same as one of Right without a fingerprint
+			lcs.maybeInRight[x] = others
+			continue
+		}
+		found := true
+		for i := uint64(0); i < bloomHashes && found; i++ {
+			bit := (hash1 + i*hash2) % size
+			found = bits[bit/64]&(1<<(bit%64)) != 0
+		}
+		lcs.maybeInRight[x] = found
+	}
+}
+
+// bloomHash derives the two hashes of the double hashing from the fingerprint
+// of a key. ok is false when the key has no fingerprint.
+func (lcs *lcs) bloomHash(key interface{}) (hash1, hash2 uint64, ok bool) {
+	var fingerprint uint64
+	if lcs.fingerprint != nil {
+		fingerprint = lcs.fingerprint(key)
+	} else if isBasicValue(key) {
+		fingerprint = hashValue(positiveZero(key))
+	} else {
+		return 0, 0, false
+	}
+	return mix64(fingerprint), mix64(fingerprint^0x5bd1e995) | 1, true
+}
+
+// positiveZero turns a negative zero of a float or a complex number into a
+// positive one, as the default equality finds them the same but %#v writes
+// them differently.
+func positiveZero(key interface{}) interface{} {
+	value := reflect.ValueOf(key)
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if value.Float() == 0 {
+			return reflect.Zero(value.Type()).Interface()
+		}
+	case reflect.Complex64, reflect.Complex128:
+		c := value.Complex()
+		if real(c) == 0 || imag(c) == 0 {
+			r, i := real(c), imag(c)
+			if r == 0 {
+				r = 0
+			}
+			if i == 0 {
+				i = 0
+			}
+			normalized := reflect.New(value.Type()).Elem()
+			normalized.SetComplex(complex(r, i))
+			return normalized.Interface()
+		}
+	}
+	return key
+}
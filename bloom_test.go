@@ -0,0 +1,120 @@
+package golcs
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithBloomPrefilter(t *testing.T) {
+	type record struct{ id int }
+	cases := []struct {
+		left        []interface{}
+		right       []interface{}
+		fingerprint func(interface{}) uint64
+		calls       int
+	}{
+		{
+			left:        []interface{}{1, 2, 3, 4, 5},
+			right:       []interface{}{9, 3, 8, 5},
+			fingerprint: intFingerprint,
+			calls:       8, // only 3 and 5 of Left are compared
+		},
+		{
+			left:        []interface{}{"a", "b"},
+			right:       []interface{}{"c", "d"},
+			fingerprint: hashValue,
+			calls:       0,
+		},
+		{
+			left:        []interface{}{1, 2, 3},
+			right:       []interface{}{1, 2, 3},
+			fingerprint: intFingerprint,
+			calls:       9,
+		},
+	}
+
+	for i, c := range cases {
+		calls := 0
+		newLcs := New(c.left, c.right, WithEqual(func(a, b interface{}) bool {
+			calls++
+			return reflect.DeepEqual(a, b)
+		}), WithBloomPrefilter(c.fingerprint))
+
+		newLcs.Table()
+		if calls != c.calls {
+			t.Errorf("test case %d failed at calls, actual: %d, expected: %d", i, calls, c.calls)
+		}
+		expected := New(c.left, c.right).IndexPairs()
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+
+	// the default fingerprints
+	left := []interface{}{1, "a", record{1}, 2.5, record{2}}
+	right := []interface{}{record{1}, "a", 2.5, record{2}, 7}
+	expected := New(left, right).IndexPairs()
+	if actual := New(left, right, WithBloomPrefilter(nil)).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at default fingerprints, actual: %v, expected: %v", actual, expected)
+	}
+}
+
+func TestWithBloomPrefilterRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(50), 40)
+		right := randomInts(random, random.Intn(50), 40)
+		expected := New(left, right).IndexPairs()
+		if actual := New(left, right, WithBloomPrefilter(nil)).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, expected)
+		}
+		if actual := Prepare(left, WithBloomPrefilter(nil)).Diff(right).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed at prepared, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+}
+
+func benchmarkSparseExpensiveEqual(b *testing.B, opts ...Option) {
+	random := rand.New(rand.NewSource(1))
+	// few elements of Left are in Right
+	left := randomInts(random, 100, 1000)
+	right := randomInts(random, 100, 1000)
+	expensive := WithEqual(func(a, b interface{}) bool {
+		same := false
+		for i := 0; i < 10; i++ {
+			same = fmt.Sprintf("%08d", a) == fmt.Sprintf("%08d", b)
+		}
+		return same
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(left, right, append(opts, expensive)...).IndexPairs()
+	}
+}
+
+func BenchmarkSparseExpensiveEqual(b *testing.B) {
+	benchmarkSparseExpensiveEqual(b)
+}
+
+func BenchmarkSparseExpensiveEqualWithBloomPrefilter(b *testing.B) {
+	benchmarkSparseExpensiveEqual(b, WithBloomPrefilter(intFingerprint))
+}
+
+func intFingerprint(value interface{}) uint64 {
+	return uint64(value.(int))
+}
+
+func TestWithBloomPrefilterNegativeZero(t *testing.T) {
+	negative := math.Copysign(0, -1)
+	left := []interface{}{negative, float32(negative), complex(negative, 1), complex64(complex(1, negative))}
+	right := []interface{}{0.0, float32(0), complex(0, 1), complex64(complex(1, 0))}
+
+	expected := New(left, right).Length()
+	if actual := New(left, right, WithBloomPrefilter(nil)).Length(); actual != expected || actual != len(left) {
+		t.Errorf("failed, actual: %v, expected: %v", actual, expected)
+	}
+}
@@ -0,0 +1,65 @@
+package golcs
+
+import "reflect"
+
+// WithComparisonCache remembers the result of the equality for each pair of
+// elements so that it is called only once for them.
+//
+// Elements are identified by their values for booleans, numbers and strings,
+// and by their addresses for pointers and channels; two elements
+// with the same identity share the results, which saves calls of an expensive
+// equality given with WithEqual() on arrays with many duplicates. Any other
+// element is identified by its position only. The equality must always give
+// the same result for the same elements. The cache is never evicted: it grows
+// up to one entry for each pair of distinct identities compared, and is
+// released with the LCS calculator.
+func WithComparisonCache() Option {
+	return func(lcs *lcs) {
+		lcs.comparisonCache = map[[2]int]bool{}
+	}
+}
+
+func (lcs *lcs) matchWithCache(x, y int) bool {
+	if lcs.leftIDs == nil {
+		identities := map[interface{}]int{}
+		lcs.leftIDs = identify(lcs.leftKeys, identities)
+		lcs.rightIDs = identify(lcs.rightKeys, identities)
+	}
+
+	key := [2]int{lcs.leftIDs[x], lcs.rightIDs[y]}
+	if same, ok := lcs.comparisonCache[key]; ok {
+		return same
+	}
+	same := lcs.compare(x, y)
+	lcs.comparisonCache[key] = same
+	return same
+}
+
+// identify gives an ID to each value, which is shared by values with the same
+// identity registered in identities.
+func identify(values []interface{}, identities map[interface{}]int) []int {
+	ids := make([]int, len(values))
+	for i, value := range values {
+		var identity interface{}
+		if isBasicValue(value) {
+			identity = value
+		} else {
+			switch v := reflect.ValueOf(value); v.Kind() {
+			case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+				identity = [2]interface{}{v.Type(), v.Pointer()}
+			}
+		}
+
+		id, ok := identities[identity]
+		if identity == nil || !ok {
+			id = len(identities)
+			if identity == nil {
+				// a unique identity which no other value shares
+				identity = &ids[i]
+			}
+			identities[identity] = id
+		}
+		ids[i] = id
+	}
+	return ids
+}
@@ -0,0 +1,78 @@
+package golcs
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithComparisonCache(t *testing.T) {
+	type point struct{ x, y int }
+	p1, p2 := &point{1, 2}, &point{1, 2}
+
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+		calls int
+	}{
+		{
+			left:  []interface{}{1, 2, 1, 2, 1},
+			right: []interface{}{2, 1, 2, 2},
+			calls: 4,
+		},
+		{
+			left:  []interface{}{p1, p2, p1},
+			right: []interface{}{p2, p1},
+			calls: 4,
+		},
+		{
+			left:  []interface{}{point{1, 2}, point{1, 2}},
+			right: []interface{}{point{1, 2}},
+			calls: 2,
+		},
+	}
+
+	for i, c := range cases {
+		calls := 0
+		newLcs := New(c.left, c.right, WithComparisonCache(), WithEqual(func(a, b interface{}) bool {
+			calls++
+			return reflect.DeepEqual(a, b)
+		}))
+
+		expected := New(c.left, c.right).IndexPairs()
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, actual, expected)
+		}
+		newLcs.SuffixTable()
+		if calls != c.calls {
+			t.Errorf("test case %d failed at calls, actual: %d, expected: %d", i, calls, c.calls)
+		}
+	}
+}
+
+func benchmarkExpensiveEqual(b *testing.B, opts ...Option) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 100, 5)
+	right := randomInts(random, 100, 5)
+	expensive := WithEqual(func(a, b interface{}) bool {
+		same := false
+		for i := 0; i < 10; i++ {
+			same = fmt.Sprintf("%08d", a) == fmt.Sprintf("%08d", b)
+		}
+		return same
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(left, right, append(opts, expensive)...).IndexPairs()
+	}
+}
+
+func BenchmarkExpensiveEqual(b *testing.B) {
+	benchmarkExpensiveEqual(b)
+}
+
+func BenchmarkExpensiveEqualWithComparisonCache(b *testing.B) {
+	benchmarkExpensiveEqual(b, WithComparisonCache())
+}
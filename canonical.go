@@ -0,0 +1,40 @@
+package golcs
+
+import "context"
+
+// WithCanonicalize compares elements by their canonical forms given by
+// canonicalize, for example an enum value for each of its aliases.
+//
+// It is WithKey() under a name for canonical forms: the elements are matched
+// by canonicalize and Values() still returns the original elements of Left,
+// but the canonical forms are also a result: CanonicalValues() returns them
+// for the matched elements, so that a caller can show which form two
+// different encodings agreed on.
+func WithCanonicalize(canonicalize func(interface{}) interface{}) Option {
+	return WithKey(canonicalize)
+}
+
+// CanonicalValues implements LCS.CanonicalValues()
+func (lcs *lcs) CanonicalValues() []interface{} {
+	values, _ := lcs.CanonicalValuesContext(context.Background())
+	return values
+}
+
+// CanonicalValuesContext implements LCS.CanonicalValuesContext()
+//
+// The canonical values are the elements of Left in Values() as they are
+// compared, that is, after WithCanonicalize() and the other options changing
+// the elements to compare in order. Without them, they are the same as
+// Values().
+func (lcs *lcs) CanonicalValuesContext(ctx context.Context) ([]interface{}, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(pairs))
+	for i, pair := range pairs {
+		values[i] = lcs.leftKeys[pair.Left]
+	}
+	return values, nil
+}
@@ -0,0 +1,40 @@
+package golcs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithCanonicalize(t *testing.T) {
+	aliases := map[string]string{"colour": "color", "grey": "gray", "centre": "center"}
+	canonicalize := func(value interface{}) interface{} {
+		if canonical, ok := aliases[value.(string)]; ok {
+			return canonical
+		}
+		return value
+	}
+
+	left := []interface{}{"colour", "size", "grey", "weight"}
+	right := []interface{}{"color", "gray", "weight", "centre"}
+
+	newLcs := New(left, right, WithCanonicalize(canonicalize))
+	if expected := []interface{}{"colour", "grey", "weight"}; !reflect.DeepEqual(newLcs.Values(), expected) {
+		t.Errorf("failed at values, actual: %v, expected: %v", newLcs.Values(), expected)
+	}
+	if expected := []interface{}{"color", "gray", "weight"}; !reflect.DeepEqual(newLcs.CanonicalValues(), expected) {
+		t.Errorf("failed at canonical values, actual: %v, expected: %v", newLcs.CanonicalValues(), expected)
+	}
+
+	// without the option only the exact match remains
+	if expected := []interface{}{"weight"}; !reflect.DeepEqual(New(left, right).CanonicalValues(), expected) {
+		t.Errorf("failed at plain canonical values, actual: %v, expected: %v", New(left, right).CanonicalValues(), expected)
+	}
+
+	// the canonical forms come after the normalizers given before
+	lower := func(value interface{}) interface{} { return strings.ToLower(value.(string)) }
+	newLcs = New([]interface{}{"GREY"}, []interface{}{"gray"}, WithNormalizers(lower), WithCanonicalize(canonicalize))
+	if expected := []interface{}{"gray"}; !reflect.DeepEqual(newLcs.CanonicalValues(), expected) {
+		t.Errorf("failed at normalized canonical values, actual: %v, expected: %v", newLcs.CanonicalValues(), expected)
+	}
+}
@@ -0,0 +1,164 @@
+package golcs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// ErrInvalidCheckpoint is returned by ResumeFromCheckpoint when a checkpoint
+// is broken, of an unknown version, or made from different arrays.
+var ErrInvalidCheckpoint = errors.New("golcs: invalid checkpoint")
+
+const (
+	checkpointMagic   = "golcs"
+	checkpointVersion = 2
+)
+
+// Checkpoint implements LCS.Checkpoint()
+//
+// When TableContext() or a method depending on it is canceled, the rows of
+// the memo table finished so far are kept, and calling the method again
+// continues from there. Checkpoint encodes those rows, or the whole table
+// once it is complete, so that the calculation can be continued in another
+// process with ResumeFromCheckpoint(). It returns nil when the table has not
+// been started.
+//
+// A checkpoint starts with "golcs" and a version number, which is increased
+// whenever the format changes; older versions are rejected. It is valid only
+// for the same arrays and the same equality: the sizes and a hash of the
+// elements formatted with %#v are recorded to detect other arrays, but a change
+// of the equality given by an Option cannot be detected. The cells end with
+// an FNV-1a hash of their bytes to detect a corrupted checkpoint.
+func (lcs *lcs) Checkpoint() []byte {
+	table, rows := lcs.table, len(lcs.right)+1
+	if table == nil {
+		table, rows = lcs.partialTable, lcs.partialRows
+	}
+	if table == nil {
+		return nil
+	}
+
+	buffer := &bytes.Buffer{}
+	buffer.WriteString(checkpointMagic)
+	buffer.WriteByte(checkpointVersion)
+	writeUvarint(buffer, uint64(len(lcs.left)))
+	writeUvarint(buffer, uint64(len(lcs.right)))
+	_ = binary.Write(buffer, binary.BigEndian, fingerprint(lcs.left, lcs.right))
+	writeUvarint(buffer, uint64(rows))
+	start := buffer.Len()
+	for y := 0; y < rows; y++ {
+		for x := range table {
+			writeUvarint(buffer, uint64(table[x][y]))
+		}
+	}
+	_ = binary.Write(buffer, binary.BigEndian, checksum(buffer.Bytes()[start:]))
+	return buffer.Bytes()
+}
+
+// ResumeFromCheckpoint creates a new LCS calculator from two arrays and a
+// checkpoint made by Checkpoint() for the same arrays and options. The memo
+// table continues from the checkpoint when it is needed.
+//
+// Besides the hash of the cells, each cell is checked against the invariants
+// the memo table keeps whatever the elements: it is 0 in the first row and
+// column, at most min(x, y), no less than the cells before it in x and in y
+// and at most one more than the cell before it in both. Whether a cell is
+// one more exactly where the elements match is not checked, as it would cost
+// the comparisons the checkpoint saves. A checkpoint failing any of them is
+// ErrInvalidCheckpoint.
+func ResumeFromCheckpoint(left, right []interface{}, token []byte, opts ...Option) (LCS, error) {
+	reader := bytes.NewReader(token)
+	magic := make([]byte, len(checkpointMagic))
+	if _, err := reader.Read(magic); err != nil || string(magic) != checkpointMagic {
+		return nil, ErrInvalidCheckpoint
+	}
+	if version, err := reader.ReadByte(); err != nil || version != checkpointVersion {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	sizeLeft, err1 := binary.ReadUvarint(reader)
+	sizeRight, err2 := binary.ReadUvarint(reader)
+	var hash uint64
+	err3 := binary.Read(reader, binary.BigEndian, &hash)
+	rows, err4 := binary.ReadUvarint(reader)
+	if err := firstError(err1, err2, err3, err4); err != nil ||
+		sizeLeft != uint64(len(left)) || sizeRight != uint64(len(right)) ||
+		hash != fingerprint(left, right) || rows == 0 || rows > sizeRight+1 {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	sizeX := len(left) + 1
+	sizeY := len(right) + 1
+	table := make([][]int, sizeX)
+	for x := 0; x < sizeX; x++ {
+		table[x] = make([]int, sizeY)
+	}
+	start := len(token) - reader.Len()
+	for y := 0; y < int(rows); y++ {
+		for x := 0; x < sizeX; x++ {
+			value, err := binary.ReadUvarint(reader)
+			if err != nil || value > uint64(min(x, y)) {
+				return nil, ErrInvalidCheckpoint
+			}
+			table[x][y] = int(value)
+			if x > 0 && y > 0 && !validCell(table, x, y) {
+				return nil, ErrInvalidCheckpoint
+			}
+		}
+	}
+	cells := token[start : len(token)-reader.Len()]
+	if err := binary.Read(reader, binary.BigEndian, &hash); err != nil || hash != checksum(cells) || reader.Len() != 0 {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	lcs := New(left, right, opts...).(*lcs)
+	if int(rows) == sizeY {
+		lcs.table = table
+	} else {
+		lcs.partialTable, lcs.partialRows = table, int(rows)
+	}
+	return lcs, nil
+}
+
+// validCell reports whether table[x][y] keeps the invariants of the memo
+// table with the cells before it.
+func validCell(table [][]int, x, y int) bool {
+	value := table[x][y]
+	return value >= table[x-1][y] && value >= table[x][y-1] && value <= table[x-1][y-1]+1
+}
+
+// checksum hashes the bytes of the cells of a checkpoint.
+func checksum(cells []byte) uint64 {
+	hash := fnv.New64a()
+	hash.Write(cells)
+	return hash.Sum64()
+}
+
+// fingerprint hashes the elements of two arrays.
+func fingerprint(left, right []interface{}) uint64 {
+	hash := fnv.New64a()
+	for _, values := range [][]interface{}{left, right} {
+		for _, value := range values {
+			fmt.Fprintf(hash, "%#v\x00", value)
+		}
+		hash.Write([]byte{0xff})
+	}
+	return hash.Sum64()
+}
+
+func writeUvarint(buffer *bytes.Buffer, value uint64) {
+	var encoded [binary.MaxVarintLen64]byte
+	buffer.Write(encoded[:binary.PutUvarint(encoded[:], value)])
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package golcs
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// cancelAfterContext is canceled after its Done() is called n times.
+type cancelAfterContext struct {
+	context.Context
+	n int
+}
+
+func (ctx *cancelAfterContext) Done() <-chan struct{} {
+	ctx.n--
+	if ctx.n < 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	return nil
+}
+
+func (ctx *cancelAfterContext) Err() error {
+	if ctx.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestCheckpoint(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 30, 3)
+	right := randomInts(random, 40, 3)
+	expected := New(left, right).IndexPairs()
+
+	if token := New(left, right).Checkpoint(); token != nil {
+		t.Errorf("unexpected checkpoint before calculation: %v", token)
+	}
+
+	newLcs := New(left, right)
+	_, err := newLcs.IndexPairsContext(&cancelAfterContext{Context: context.Background(), n: 10})
+	if err != context.Canceled {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// resume twice, in the same instance and from the checkpoint
+	token := newLcs.Checkpoint()
+	for i := 0; i < 2; i++ {
+		_, err := newLcs.IndexPairsContext(&cancelAfterContext{Context: context.Background(), n: 10})
+		if err != context.Canceled {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed to continue, actual: %v, expected: %v", actual, expected)
+	}
+
+	resumed, err := ResumeFromCheckpoint(left, right, token)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if actual := resumed.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed to resume, actual: %v, expected: %v", actual, expected)
+	}
+
+	// a complete table is also a valid checkpoint
+	completed, err := ResumeFromCheckpoint(left, right, newLcs.Checkpoint())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !reflect.DeepEqual(completed.(*lcs).table, newLcs.(*lcs).table) {
+		t.Errorf("failed to resume a complete table")
+	}
+}
+
+// corrupt returns a copy of a token with the byte at i replaced.
+func corrupt(token []byte, i int, b byte) []byte {
+	corrupted := append([]byte{}, token...)
+	corrupted[i] = b
+	return corrupted
+}
+
+// withChecksum replaces the checksum of a token of 3 and 2 elements with one
+// of its cells, which start after the header of 5+1+1+1+8+1 bytes.
+func withChecksum(token []byte) []byte {
+	fixed := append([]byte{}, token[:len(token)-8]...)
+	var sum [8]byte
+	binary.BigEndian.PutUint64(sum[:], checksum(fixed[17:]))
+	return append(fixed, sum[:]...)
+}
+
+func TestResumeFromInvalidCheckpoint(t *testing.T) {
+	left := []interface{}{1, 2, 3}
+	right := []interface{}{1, 3}
+	newLcs := New(left, right)
+	newLcs.IndexPairs()
+	token := newLcs.Checkpoint()
+
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+		token []byte
+	}{
+		{left: left, right: right, token: nil},
+		{left: left, right: right, token: []byte("other")},
+		{left: left, right: right, token: append([]byte("golcs\x01"), token[6:]...)},
+		{left: left, right: right, token: token[:len(token)-1]},
+		{left: left, right: right, token: append(append([]byte{}, token...), 0)},
+		{left: []interface{}{1, 2, 4}, right: right, token: token},
+		{left: left, right: []interface{}{1}, token: token},
+		// corrupted cells
+		{left: left, right: right, token: corrupt(token, len(token)-9, 0x7f)},
+		{left: left, right: right, token: corrupt(token, len(token)-1, 0x7f)},
+		{left: left, right: right, token: withChecksum(corrupt(token, len(token)-9, 0x7f))},
+		{left: left, right: right, token: withChecksum(corrupt(token, len(token)-10, 0))},
+	}
+
+	if _, err := ResumeFromCheckpoint(left, right, withChecksum(token)); err != nil {
+		t.Fatalf("failed at the checksum, unexpected err: %v", err)
+	}
+	for i, c := range cases {
+		if _, err := ResumeFromCheckpoint(c.left, c.right, c.token); err != ErrInvalidCheckpoint {
+			t.Errorf("test case %d failed, unexpected err: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package golcs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+)
+
+// ColorMode tells WriteColorUnified whether to color the diff.
+type ColorMode int
+
+const (
+	// ColorAuto colors the diff when it is written to a terminal.
+	ColorAuto ColorMode = iota
+	// ColorNever never colors the diff.
+	ColorNever
+	// ColorAlways always colors the diff.
+	ColorAlways
+)
+
+// unifiedColors are the ANSI escape sequences of a unified diff, which are
+// empty for no color.
+type unifiedColors struct {
+	header string
+	delete string
+	insert string
+	reset  string
+}
+
+// WriteColorUnified implements LCS.WriteColorUnified()
+func (lcs *lcs) WriteColorUnified(w io.Writer, contextSize int, colorize ColorMode) error {
+	return lcs.WriteColorUnifiedContext(context.Background(), w, contextSize, colorize)
+}
+
+// WriteColorUnifiedContext implements LCS.WriteColorUnifiedContext()
+//
+// The diff is the hunks of PatchFile() with contextSize lines of context,
+// without the file headers. With colors, as git diff shows them, hunk headers
+// are cyan, deleted lines red and inserted lines green, while the unchanged
+// lines and the "\ No newline at end of file" markers are not colored; the
+// colors end before the line terminators. ColorAuto colors the diff when w
+// is an *os.File of a terminal. The edit script is calculated first, and an
+// error of the calculation, such as one of WithEqualErr(), is returned before
+// writing anything. Each hunk is then written to w through a buffer as soon
+// as it is formatted, without holding the other hunks, and the writing stops
+// at the first error of w or when ctx is done.
+func (lcs *lcs) WriteColorUnifiedContext(ctx context.Context, w io.Writer, contextSize int, colorize ColorMode) error {
+	colors := unifiedColors{}
+	if colorize == ColorAlways || (colorize == ColorAuto && isTerminal(w)) {
+		colors = unifiedColors{header: "\x1b[36m", delete: "\x1b[31m", insert: "\x1b[32m", reset: "\x1b[0m"}
+	}
+
+	edits, err := lcs.EditScriptContext(ctx)
+	if err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(w)
+	eachHunk(edits, contextSize, func(hunk Hunk) bool {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return false
+		default:
+			// nop
+		}
+		writeUnifiedHunk(buffered, hunk, colors)
+		// the error of a write is kept by the buffer
+		_, err = buffered.Write(nil)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,119 @@
+package golcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteColorUnified(t *testing.T) {
+	newLcs := NewLines("a\nb\nc\n", "a\nB\nc")
+
+	plain := "@@ -1,3 +1,3 @@\n" +
+		" a\n" +
+		"-b\n" +
+		"-c\n" +
+		"+B\n" +
+		"+c\n" +
+		"\\ No newline at end of file\n"
+	colored := "\x1b[36m@@ -1,3 +1,3 @@\x1b[0m\n" +
+		" a\n" +
+		"\x1b[31m-b\x1b[0m\n" +
+		"\x1b[31m-c\x1b[0m\n" +
+		"\x1b[32m+B\x1b[0m\n" +
+		"\x1b[32m+c\x1b[0m\n" +
+		"\\ No newline at end of file\n"
+
+	cases := []struct {
+		colorize ColorMode
+		expected string
+	}{
+		{colorize: ColorNever, expected: plain},
+		{colorize: ColorAlways, expected: colored},
+		// a buffer is not a terminal
+		{colorize: ColorAuto, expected: plain},
+	}
+	for i, c := range cases {
+		buffer := &bytes.Buffer{}
+		if err := newLcs.WriteColorUnified(buffer, 3, c.colorize); err != nil {
+			t.Fatal(err)
+		}
+		if actual := buffer.String(); actual != c.expected {
+			t.Errorf("test case %d failed, actual: %q, expected: %q", i, actual, c.expected)
+		}
+	}
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "diff"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if isTerminal(file) {
+		t.Errorf("a regular file is a terminal")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteColorUnifiedError(t *testing.T) {
+	if err := NewLines("a\n", "b\n").WriteColorUnified(failingWriter{}, 3, ColorAlways); err == nil {
+		t.Errorf("the error of the writer is not returned")
+	}
+}
+
+func TestWriteColorUnifiedCalculationError(t *testing.T) {
+	expected := errors.New("compare failed")
+	newLcs := NewLines("a\n", "b\n", WithEqualErr(func(a, b interface{}) (bool, error) {
+		return false, expected
+	}))
+	var buffer bytes.Buffer
+	if err := newLcs.WriteColorUnified(&buffer, 3, ColorNever); err != expected {
+		t.Errorf("failed at error, actual: %v, expected: %v", err, expected)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("failed at output, actual: %q", buffer.String())
+	}
+}
+
+// countingWriter fails after limit writes.
+type countingWriter struct {
+	writes int
+	limit  int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.writes >= w.limit {
+		return 0, errors.New("write failed")
+	}
+	w.writes++
+	return len(p), nil
+}
+
+func TestWriteColorUnifiedContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var buffer bytes.Buffer
+	if err := NewLines("a\n", "b\n").WriteColorUnifiedContext(ctx, &buffer, 3, ColorNever); err != context.Canceled {
+		t.Errorf("failed at canceled, actual: %v, expected: %v", err, context.Canceled)
+	}
+
+	// the hunks are written to the buffer as they are formatted, which writes
+	// to w each time it is full, and the writing stops at the first error
+	left := strings.Repeat("=\n"+strings.Repeat("a", 1000)+"\n", 20)
+	right := strings.Repeat("=\n"+strings.Repeat("b", 1000)+"\n", 20)
+	writer := &countingWriter{limit: 1}
+	if err := NewLines(left, right).WriteColorUnifiedContext(context.Background(), writer, 0, ColorNever); err == nil {
+		t.Errorf("the error of the writer is not returned")
+	}
+	if writer.writes != 1 {
+		t.Errorf("failed at writes, actual: %v, expected: %v", writer.writes, 1)
+	}
+}
@@ -0,0 +1,167 @@
+package golcs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrNotBytes is returned by BinaryDeltaContext() when an inserted element is not a byte.
+var ErrNotBytes = errors.New("golcs: element is not a byte")
+
+// ErrInvalidDelta is returned by ApplyBinaryDelta when a delta is malformed or does not match the bytes.
+var ErrInvalidDelta = errors.New("golcs: delta does not match the bytes")
+
+// NewBytes creates a new LCS calculator comparing two byte slices byte by
+// byte. The elements are the bytes.
+func NewBytes(a, b []byte, opts ...Option) LCS {
+	return New(splitBytes(a), splitBytes(b), opts...)
+}
+
+func splitBytes(data []byte) []interface{} {
+	values := make([]interface{}, len(data))
+	for i, b := range data {
+		values[i] = b
+	}
+	return values
+}
+
+// BinaryDelta implements LCS.BinaryDelta()
+func (lcs *lcs) BinaryDelta() []byte {
+	delta, _ := lcs.BinaryDeltaContext(context.Background())
+	return delta
+}
+
+// BinaryDeltaContext implements LCS.BinaryDeltaContext()
+//
+// The delta is Operations() written one after another: each operation is a
+// byte of its OperationKind and its Count as an unsigned varint, and an
+// insert is followed by its Count bytes. ApplyBinaryDelta() applies it to
+// Left to get Right. Only the inserted elements are written, so Right must be
+// bytes, as NewBytes() makes them; otherwise it returns ErrNotBytes.
+func (lcs *lcs) BinaryDeltaContext(ctx context.Context) ([]byte, error) {
+	operations, err := lcs.OperationsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := []byte{}
+	count := make([]byte, binary.MaxVarintLen64)
+	for _, operation := range operations {
+		delta = append(delta, byte(operation.Kind))
+		delta = append(delta, count[:binary.PutUvarint(count, uint64(operation.Count))]...)
+		for _, value := range operation.Values {
+			b, ok := value.(byte)
+			if !ok {
+				return nil, ErrNotBytes
+			}
+			delta = append(delta, b)
+		}
+	}
+	return delta, nil
+}
+
+// ApplyBinaryDelta applies a delta such as BinaryDelta() to bytes.
+// It returns ErrInvalidDelta when the delta is malformed or its retains and
+// deletes do not cover left exactly.
+func ApplyBinaryDelta(left, delta []byte) ([]byte, error) {
+	result := make([]byte, 0, len(left))
+	x := 0
+	for len(delta) > 0 {
+		kind := OperationKind(delta[0])
+		count, size := binary.Uvarint(delta[1:])
+		if size <= 0 {
+			return nil, ErrInvalidDelta
+		}
+		delta = delta[1+size:]
+		switch kind {
+		case OperationRetain, OperationDelete:
+			if count > uint64(len(left)-x) {
+				return nil, ErrInvalidDelta
+			}
+			if kind == OperationRetain {
+				result = append(result, left[x:x+int(count)]...)
+			}
+			x += int(count)
+		case OperationInsert:
+			if count > uint64(len(delta)) {
+				return nil, ErrInvalidDelta
+			}
+			result = append(result, delta[:count]...)
+			delta = delta[count:]
+		default:
+			return nil, ErrInvalidDelta
+		}
+	}
+	if x != len(left) {
+		return nil, ErrInvalidDelta
+	}
+	return result, nil
+}
+
+// PatchSizeEstimate implements LCS.PatchSizeEstimate()
+func (lcs *lcs) PatchSizeEstimate() int {
+	size, _ := lcs.PatchSizeEstimateContext(context.Background())
+	return size
+}
+
+// PatchSizeEstimateContext implements LCS.PatchSizeEstimateContext()
+//
+// The estimate is the size in bytes of Operations() in the format of
+// BinaryDelta(): each operation takes a byte of its kind and the varint of its
+// Count, so a run of copied or deleted elements costs a few bytes whatever its
+// length, and an insert costs its elements serialized as well. A byte, a
+// bool, an int8 and a uint8 take 1 byte, the other numbers their fixed sizes,
+// with 8 bytes for int, uint and uintptr, and a string or a []byte its length
+// plus the varint of the length. The other elements are estimated as strings
+// written with fmt.Sprint. For bytes, the estimate is exactly the size of
+// BinaryDelta(). Comparing it with the size of Right serialized the same way
+// tells whether a delta is worth storing instead of Right itself.
+func (lcs *lcs) PatchSizeEstimateContext(ctx context.Context) (int, error) {
+	operations, err := lcs.OperationsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	size := 0
+	for _, operation := range operations {
+		size += 1 + uvarintSize(uint64(operation.Count))
+		for _, value := range operation.Values {
+			size += serializedSize(value)
+		}
+	}
+	return size, nil
+}
+
+// serializedSize estimates the bytes to serialize a value.
+func serializedSize(value interface{}) int {
+	switch v := value.(type) {
+	case bool, int8, uint8:
+		return 1
+	case int16, uint16:
+		return 2
+	case int32, uint32, float32:
+		return 4
+	case int, uint, uintptr, int64, uint64, float64, complex64:
+		return 8
+	case complex128:
+		return 16
+	case string:
+		return uvarintSize(uint64(len(v))) + len(v)
+	case []byte:
+		return uvarintSize(uint64(len(v))) + len(v)
+	default:
+		text := fmt.Sprint(v)
+		return uvarintSize(uint64(len(text))) + len(text)
+	}
+}
+
+// uvarintSize returns the bytes of v encoded as an unsigned varint.
+func uvarintSize(v uint64) int {
+	size := 1
+	for ; v >= 0x80; v >>= 7 {
+		size++
+	}
+	return size
+}
@@ -0,0 +1,83 @@
+package golcs
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestBinaryDelta(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	cases := []struct {
+		left  []byte
+		right []byte
+	}{
+		{[]byte{}, []byte{}},
+		{[]byte("abc"), []byte{}},
+		{[]byte{}, []byte("abc")},
+		{[]byte("the quick brown fox"), []byte("the quick red fox jumps")},
+		{bytes.Repeat([]byte("0123456789"), 20), append(bytes.Repeat([]byte("0123456789"), 20), 'x')},
+	}
+	for i := 0; i < 50; i++ {
+		left := make([]byte, random.Intn(300))
+		right := make([]byte, random.Intn(300))
+		random.Read(left)
+		random.Read(right)
+		cases = append(cases, struct {
+			left  []byte
+			right []byte
+		}{left, right})
+	}
+
+	for i, cs := range cases {
+		newLcs := NewBytes(cs.left, cs.right)
+		delta := newLcs.BinaryDelta()
+		applied, err := ApplyBinaryDelta(cs.left, delta)
+		if err != nil || !bytes.Equal(applied, cs.right) {
+			t.Errorf("test case %d failed at apply, actual: %v, %v, expected: %v", i, applied, err, cs.right)
+		}
+		if estimate := newLcs.PatchSizeEstimate(); estimate != len(delta) {
+			t.Errorf("test case %d failed at estimate, actual: %d, expected: %d", i, estimate, len(delta))
+		}
+	}
+}
+
+func TestPatchSizeEstimate(t *testing.T) {
+	long := randomInts(rand.New(rand.NewSource(1)), 1000, 10)
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected int
+	}{
+		// a retain of 1000 costs the kind and a varint of 2 bytes
+		{long, long, 3},
+		{[]interface{}{"a", "b"}, []interface{}{"a", "xyz", "b"}, 2 + 2 + 4 + 2},
+		{[]interface{}{1, 2}, []interface{}{int32(3)}, 2 + 2 + 4},
+	}
+
+	for i, cs := range cases {
+		if actual := New(cs.left, cs.right).PatchSizeEstimate(); actual != cs.expected {
+			t.Errorf("test case %d failed, actual: %d, expected: %d", i, actual, cs.expected)
+		}
+	}
+	if delta, err := New([]interface{}{1}, []interface{}{2}).BinaryDeltaContext(context.Background()); err != ErrNotBytes {
+		t.Errorf("failed at not bytes, actual: %v, %v, expected: %v", delta, err, ErrNotBytes)
+	}
+}
+
+func TestApplyBinaryDeltaInvalid(t *testing.T) {
+	left := []byte("abc")
+	cases := [][]byte{
+		{byte(OperationRetain), 2},
+		{byte(OperationRetain), 4},
+		{byte(OperationRetain), 3, byte(OperationInsert), 2, 'x'},
+		{byte(OperationRetain)},
+		{9, 3},
+	}
+	for i, delta := range cases {
+		if _, err := ApplyBinaryDelta(left, delta); err != ErrInvalidDelta {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, err, ErrInvalidDelta)
+		}
+	}
+}
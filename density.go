@@ -0,0 +1,42 @@
+package golcs
+
+import "context"
+
+// HunkDensity implements LCS.HunkDensity()
+func (lcs *lcs) HunkDensity(buckets int) []int {
+	counts, _ := lcs.HunkDensityContext(context.Background(), buckets)
+	return counts
+}
+
+// HunkDensityContext implements LCS.HunkDensityContext()
+//
+// The indices of Left are divided into buckets ranges of the same size, and
+// counts[b] is the number of the elements of Left not in IndexPairs() whose
+// index i is in the b-th range, which is i*buckets/len(Left) rounded down, so
+// the ranges differ in size by at most one element and the first range starts
+// at 0 and the last ends at len(Left). With more buckets than elements, each
+// element has its own bucket and the others are always 0. All the counts are
+// 0 for an empty Left. Only Left has the positions of the buckets, so the
+// elements inserted from Right are not counted. It returns empty counts for
+// buckets <= 0.
+func (lcs *lcs) HunkDensityContext(ctx context.Context, buckets int) ([]int, error) {
+	if buckets <= 0 {
+		return []int{}, nil
+	}
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]bool, len(lcs.left))
+	for _, pair := range pairs {
+		matched[pair.Left] = true
+	}
+	counts := make([]int, buckets)
+	for i := range lcs.left {
+		if !matched[i] {
+			counts[i*buckets/len(lcs.left)]++
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,42 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHunkDensity(t *testing.T) {
+	lines := func(from, to int) []interface{} {
+		values := []interface{}{}
+		for i := from; i < to; i++ {
+			values = append(values, i)
+		}
+		return values
+	}
+	// 100 lines, changed at 10-14 and 80-89
+	left := lines(0, 100)
+	right := append(append(append(lines(0, 10), lines(15, 80)...), "x", "y"), lines(90, 100)...)
+
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		buckets  int
+		expected []int
+	}{
+		{left, right, 10, []int{0, 5, 0, 0, 0, 0, 0, 0, 10, 0}},
+		{left, right, 4, []int{5, 0, 0, 10}},
+		{left, right, 1, []int{15}},
+		{left, right, 0, []int{}},
+		{left, left, 3, []int{0, 0, 0}},
+		{[]interface{}{1, 2}, []interface{}{2}, 5, []int{1, 0, 0, 0, 0}},
+		{[]interface{}{1, 2, 3}, []interface{}{}, 2, []int{2, 1}},
+		{[]interface{}{}, []interface{}{1}, 2, []int{0, 0}},
+	}
+
+	for i, cs := range cases {
+		actual := New(cs.left, cs.right).HunkDensity(cs.buckets)
+		if !reflect.DeepEqual(actual, cs.expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, cs.expected)
+		}
+	}
+}
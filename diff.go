@@ -0,0 +1,517 @@
+package golcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrInvalidEditScript is returned by Apply when an edit script does not match the array.
+var ErrInvalidEditScript = errors.New("golcs: edit script does not match the array")
+
+// EditKind represents the kind of an Edit.
+type EditKind int
+
+const (
+	// EditEqual keeps an element which is in both Left and Right.
+	EditEqual EditKind = iota
+	// EditDelete removes an element of Left.
+	EditDelete
+	// EditInsert adds an element of Right.
+	EditInsert
+)
+
+// Edit is a step to transform Left into Right.
+type Edit struct {
+	Kind EditKind
+	// Left is the index in Left, or -1 for EditInsert.
+	Left int
+	// Right is the index in Right, or -1 for EditDelete.
+	Right int
+	// Value is the element of Right for EditInsert, otherwise of Left.
+	Value interface{}
+}
+
+// Hunk is a group of changes in an edit script surrounded by unchanged elements.
+type Hunk struct {
+	// LeftStart and LeftLength are the range of Left covered by the hunk.
+	LeftStart  int
+	LeftLength int
+	// RightStart and RightLength are the range of Right covered by the hunk.
+	RightStart  int
+	RightLength int
+	// Edits are the edits in the hunk including the surrounding EditEqual.
+	Edits []Edit
+}
+
+// NewLines creates a new LCS calculator comparing two texts line by line.
+// See SplitLines for how the texts are split.
+func NewLines(a, b string, opts ...Option) LCS {
+	split := SplitLines
+	if resolveLineOptions(opts).normalizeNewlines {
+		split = splitLinesAnyTerminator
+	}
+	return New(split(a), split(b), opts...)
+}
+
+// lineOptions are the options deciding how NewLines() splits the texts.
+type lineOptions struct {
+	normalizeNewlines bool
+}
+
+// resolveLineOptions reads lineOptions from the options by applying them to
+// bare fields only, without the keys and the states of a calculator.
+func resolveLineOptions(opts []Option) lineOptions {
+	var fields lcs
+	for _, opt := range opts {
+		opt(&fields)
+	}
+	return lineOptions{normalizeNewlines: fields.normalizeNewlines}
+}
+
+// SplitLines splits a text into lines as strings. Each line keeps its "\n"
+// terminator, so the last line of a text not ending with a newline is the only
+// one without it. An empty text has no lines.
+func SplitLines(text string) []interface{} {
+	lines := []interface{}{}
+	for len(text) > 0 {
+		end := strings.IndexByte(text, '\n') + 1
+		if end == 0 {
+			end = len(text)
+		}
+		lines = append(lines, text[:end])
+		text = text[end:]
+	}
+	return lines
+}
+
+// splitLinesAnyTerminator is SplitLines also splitting lines at "\r" not followed by "\n".
+func splitLinesAnyTerminator(text string) []interface{} {
+	lines := []interface{}{}
+	for len(text) > 0 {
+		end := strings.IndexAny(text, "\r\n") + 1
+		if end == 0 {
+			end = len(text)
+		} else if text[end-1] == '\r' && end < len(text) && text[end] == '\n' {
+			end++
+		}
+		lines = append(lines, text[:end])
+		text = text[end:]
+	}
+	return lines
+}
+
+// EditScript implements LCS.EditScript()
+func (lcs *lcs) EditScript() []Edit {
+	edits, _ := lcs.EditScriptContext(context.Background())
+	return edits
+}
+
+// EditScriptContext implements LCS.EditScriptContext()
+//
+// The script has an Edit for every element of Left and Right in their order.
+// Between two common elements, deletions come before insertions.
+func (lcs *lcs) EditScriptContext(ctx context.Context) ([]Edit, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	edits, _ := lcs.editScript(pairs, len(lcs.left)+len(lcs.right)-len(pairs))
+	return edits, nil
+}
+
+// EditScriptLimited implements LCS.EditScriptLimited()
+func (lcs *lcs) EditScriptLimited(limit int) ([]Edit, bool) {
+	edits, truncated, _ := lcs.EditScriptLimitedContext(context.Background(), limit)
+	return edits, truncated
+}
+
+// EditScriptLimitedContext implements LCS.EditScriptLimitedContext()
+//
+// The edits are the first limit edits of EditScript(), including EditEqual,
+// walking from the start of the arrays; the end of the script is what is cut
+// off, and truncated reports whether it had more edits. Only the returned
+// edits are created. Length() and EditCount() still count the full diff.
+func (lcs *lcs) EditScriptLimitedContext(ctx context.Context, limit int) (edits []Edit, truncated bool, err error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	edits, truncated = lcs.editScript(pairs, max(limit, 0))
+	return edits, truncated, nil
+}
+
+// EditCount implements LCS.EditCount()
+func (lcs *lcs) EditCount() int {
+	count, _ := lcs.EditCountContext(context.Background())
+	return count
+}
+
+// EditCountContext implements LCS.EditCountContext()
+//
+// The count is the number of EditDelete and EditInsert in EditScript(),
+// which is len(Left) + len(Right) - 2*Length().
+func (lcs *lcs) EditCountContext(ctx context.Context) (int, error) {
+	length, err := lcs.LengthContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(lcs.left) + len(lcs.right) - 2*length, nil
+}
+
+// editScript creates up to limit edits of the script of pairs and reports
+// whether the script has more.
+func (lcs *lcs) editScript(pairs []IndexPair, limit int) ([]Edit, bool) {
+	edits := make([]Edit, 0, min(limit, len(lcs.left)+len(lcs.right)-len(pairs)))
+	add := func(edit Edit) bool {
+		if len(edits) == limit {
+			return false
+		}
+		edits = append(edits, edit)
+		return true
+	}
+
+	x, y := 0, 0
+	for i := 0; i <= len(pairs); i++ {
+		pair := IndexPair{Left: len(lcs.left), Right: len(lcs.right)}
+		if i < len(pairs) {
+			pair = pairs[i]
+		}
+		for ; x < pair.Left; x++ {
+			if !add(Edit{Kind: EditDelete, Left: x, Right: -1, Value: lcs.left[x]}) {
+				return edits, true
+			}
+		}
+		for ; y < pair.Right; y++ {
+			if !add(Edit{Kind: EditInsert, Left: -1, Right: y, Value: lcs.right[y]}) {
+				return edits, true
+			}
+		}
+		if i < len(pairs) {
+			if !add(Edit{Kind: EditEqual, Left: x, Right: y, Value: lcs.left[x]}) {
+				return edits, true
+			}
+			x++
+			y++
+		}
+	}
+	return edits, false
+}
+
+// ReverseEditScript implements LCS.ReverseEditScript()
+func (lcs *lcs) ReverseEditScript() []Edit {
+	edits, _ := lcs.ReverseEditScriptContext(context.Background())
+	return edits
+}
+
+// ReverseEditScriptContext implements LCS.ReverseEditScriptContext()
+//
+// The script is EditScript() with Left and Right swapped: each EditDelete
+// becomes an EditInsert and vice versa, and the Left and Right indices of
+// every Edit are swapped. An EditEqual keeps the element of Left as its Value.
+// Between two common elements, the deletions still come before the
+// insertions, so applying the script to Right gives Left.
+func (lcs *lcs) ReverseEditScriptContext(ctx context.Context) ([]Edit, error) {
+	edits, err := lcs.EditScriptContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reversed := make([]Edit, 0, len(edits))
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == EditEqual {
+			reversed = append(reversed, Edit{Kind: EditEqual, Left: edits[i].Right, Right: edits[i].Left, Value: edits[i].Value})
+			i++
+			continue
+		}
+
+		// a run of deletions and insertions between common elements
+		end := i
+		for end < len(edits) && edits[end].Kind != EditEqual {
+			end++
+		}
+		for _, edit := range edits[i:end] {
+			if edit.Kind == EditInsert {
+				reversed = append(reversed, Edit{Kind: EditDelete, Left: edit.Right, Right: -1, Value: edit.Value})
+			}
+		}
+		for _, edit := range edits[i:end] {
+			if edit.Kind == EditDelete {
+				reversed = append(reversed, Edit{Kind: EditInsert, Left: -1, Right: edit.Left, Value: edit.Value})
+			}
+		}
+		i = end
+	}
+	return reversed, nil
+}
+
+// Apply applies an edit script such as EditScript() to an array.
+// It returns ErrInvalidEditScript when the EditEqual and EditDelete of the
+// script do not visit every element of left exactly once in order.
+func Apply(left []interface{}, script []Edit) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(left))
+	x := 0
+	for _, edit := range script {
+		switch edit.Kind {
+		case EditEqual, EditDelete:
+			if edit.Left != x || x >= len(left) {
+				return nil, ErrInvalidEditScript
+			}
+			if edit.Kind == EditEqual {
+				result = append(result, left[x])
+			}
+			x++
+		case EditInsert:
+			result = append(result, edit.Value)
+		default:
+			return nil, ErrInvalidEditScript
+		}
+	}
+	if x != len(left) {
+		return nil, ErrInvalidEditScript
+	}
+	return result, nil
+}
+
+// Hunks implements LCS.Hunks()
+func (lcs *lcs) Hunks(contextSize int) []Hunk {
+	hunks, _ := lcs.HunksContext(context.Background(), contextSize)
+	return hunks
+}
+
+// HunksContext implements LCS.HunksContext()
+//
+// Each hunk has up to contextSize unchanged elements before and after its
+// changes. Changes separated by no more than 2*contextSize unchanged elements
+// share a hunk, as diff -u does.
+func (lcs *lcs) HunksContext(ctx context.Context, contextSize int) ([]Hunk, error) {
+	edits, err := lcs.EditScriptContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return hunks(edits, contextSize), nil
+}
+
+func hunks(edits []Edit, contextSize int) []Hunk {
+	result := []Hunk{}
+	eachHunk(edits, contextSize, func(hunk Hunk) bool {
+		result = append(result, hunk)
+		return true
+	})
+	return result
+}
+
+// eachHunk calls yield with the hunks of edits in order until it returns
+// false. The positions in Left and Right are carried forward from one hunk
+// to the next, so it takes O(len(edits)) time in total.
+func eachHunk(edits []Edit, contextSize int, yield func(Hunk) bool) {
+	if contextSize < 0 {
+		contextSize = 0
+	}
+
+	// left and right are the positions of edits[position]
+	position, left, right := 0, 0, 0
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == EditEqual {
+			i++
+			continue
+		}
+
+		start := i - contextSize
+		if start < 0 {
+			start = 0
+		}
+		// extend the hunk while the next change is close enough
+		last := i
+		for j := i + 1; j < len(edits) && j-last-1 <= 2*contextSize; j++ {
+			if edits[j].Kind != EditEqual {
+				last = j
+			}
+		}
+		end := last + contextSize + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		left, right = advancePositions(edits[position:start], left, right)
+		hunk := newHunk(edits, start, end, left, right)
+		position, left, right = end, left+hunk.LeftLength, right+hunk.RightLength
+		if !yield(hunk) {
+			return
+		}
+		i = end
+	}
+}
+
+// newHunk creates a Hunk from edits[start:end] starting at left and right.
+func newHunk(edits []Edit, start, end, left, right int) Hunk {
+	hunk := Hunk{Edits: edits[start:end]}
+	// the position of a hunk is that of its first element on each side, or
+	// the position of the next one when the hunk has none of the side
+	hunk.LeftStart, hunk.RightStart = left, right
+	for _, edit := range hunk.Edits {
+		if edit.Kind != EditInsert {
+			hunk.LeftLength++
+		}
+		if edit.Kind != EditDelete {
+			hunk.RightLength++
+		}
+	}
+	return hunk
+}
+
+// advancePositions returns the positions in Left and Right after edits from
+// left and right.
+func advancePositions(edits []Edit, left, right int) (int, int) {
+	for _, edit := range edits {
+		if edit.Kind != EditInsert {
+			left++
+		}
+		if edit.Kind != EditDelete {
+			right++
+		}
+	}
+	return left, right
+}
+
+// PatchFile implements LCS.PatchFile()
+//
+// The patch is in the unified format with 3 lines of context and can be
+// applied with patch -p0. Elements are written with fmt.Sprint and expected
+// to be lines with their terminators as given by SplitLines; a line without
+// the terminator is followed by the "\ No newline at end of file" marker.
+// Timestamps are written as diff -u does, for example
+// "2006-01-02 15:04:05.000000000 -0700". The patch is empty when the two
+// arrays are the same.
+func (lcs *lcs) PatchFile(oldName, newName string, oldTime, newTime time.Time) string {
+	hunks := lcs.Hunks(3)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "--- %s\t%s\n", oldName, oldTime.Format(patchTimeLayout))
+	fmt.Fprintf(builder, "+++ %s\t%s\n", newName, newTime.Format(patchTimeLayout))
+	for _, hunk := range hunks {
+		writeUnifiedHunk(builder, hunk, unifiedColors{})
+	}
+	return builder.String()
+}
+
+const patchTimeLayout = "2006-01-02 15:04:05.000000000 -0700"
+
+func writeUnifiedHunk(w io.Writer, hunk Hunk, colors unifiedColors) {
+	fmt.Fprintf(w, "%s@@ -%s +%s @@%s\n", colors.header,
+		unifiedRange(hunk.LeftStart, hunk.LeftLength),
+		unifiedRange(hunk.RightStart, hunk.RightLength), colors.reset)
+	for _, edit := range hunk.Edits {
+		switch edit.Kind {
+		case EditEqual:
+			io.WriteString(w, " ")
+			writeLine(w, edit.Value, "")
+		case EditDelete:
+			io.WriteString(w, colors.delete+"-")
+			writeLine(w, edit.Value, colors.reset)
+		case EditInsert:
+			io.WriteString(w, colors.insert+"+")
+			writeLine(w, edit.Value, colors.reset)
+		}
+	}
+}
+
+// unifiedRange formats a range in a unified hunk header. Lines are counted
+// from 1, and an empty range refers to the line before it.
+func unifiedRange(start, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, length)
+	}
+}
+
+// writeLine writes an element as a line terminated by "\n", followed by
+// reset before the terminator.
+func writeLine(w io.Writer, value interface{}, reset string) {
+	line := fmt.Sprint(value)
+	io.WriteString(w, strings.TrimSuffix(line, "\n")+reset+"\n")
+	if !strings.HasSuffix(line, "\n") {
+		io.WriteString(w, "\\ No newline at end of file\n")
+	}
+}
+
+// NormalDiff implements LCS.NormalDiff()
+//
+// The diff is in the normal format of diff without options. Each change is a
+// command followed by the lines:
+//
+//	LaR or LaR1,R2  adds the lines R1 to R2 of Right after the line L of Left
+//	L1,L2dR         deletes the lines L1 to L2 of Left, which would be after
+//	                the line R of Right
+//	L1,L2cR1,R2     changes the lines L1 to L2 of Left into R1 to R2 of Right
+//
+// where lines are counted from 1 and a range of one line is a single number.
+// The lines of Left are marked with "< " and those of Right with "> ", and a
+// "---" line separates them in a change. Elements are written like PatchFile().
+func (lcs *lcs) NormalDiff() string {
+	edits := lcs.EditScript()
+
+	builder := &strings.Builder{}
+	// left and right are the positions of edits[i]
+	left, right := 0, 0
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == EditEqual {
+			i, left, right = i+1, left+1, right+1
+			continue
+		}
+
+		end := i
+		deleted, inserted := 0, 0
+		for ; end < len(edits) && edits[end].Kind != EditEqual; end++ {
+			if edits[end].Kind == EditDelete {
+				deleted++
+			} else {
+				inserted++
+			}
+		}
+		switch {
+		case deleted == 0:
+			fmt.Fprintf(builder, "%da%s\n", left, normalRange(right, inserted))
+		case inserted == 0:
+			fmt.Fprintf(builder, "%sd%d\n", normalRange(left, deleted), right)
+		default:
+			fmt.Fprintf(builder, "%sc%s\n", normalRange(left, deleted), normalRange(right, inserted))
+		}
+		for _, edit := range edits[i:end] {
+			if edit.Kind == EditDelete {
+				builder.WriteString("< ")
+				writeLine(builder, edit.Value, "")
+			}
+		}
+		if deleted > 0 && inserted > 0 {
+			builder.WriteString("---\n")
+		}
+		for _, edit := range edits[i:end] {
+			if edit.Kind == EditInsert {
+				builder.WriteString("> ")
+				writeLine(builder, edit.Value, "")
+			}
+		}
+		i, left, right = end, left+deleted, right+inserted
+	}
+	return builder.String()
+}
+
+// normalRange formats the lines from start+1 to start+length in the normal format.
+func normalRange(start, length int) string {
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, start+length)
+}
@@ -0,0 +1,254 @@
+package golcs
+
+import (
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitLines(t *testing.T) {
+	cases := []struct {
+		text  string
+		lines []interface{}
+	}{
+		{text: "", lines: []interface{}{}},
+		{text: "a", lines: []interface{}{"a"}},
+		{text: "a\n", lines: []interface{}{"a\n"}},
+		{text: "a\n\nb", lines: []interface{}{"a\n", "\n", "b"}},
+	}
+
+	for i, c := range cases {
+		actual := SplitLines(c.text)
+		if !reflect.DeepEqual(actual, c.lines) {
+			t.Errorf("test case %d failed, actual: %#v, expected: %#v", i, actual, c.lines)
+		}
+	}
+}
+
+func TestEditScript(t *testing.T) {
+	newLcs := New([]interface{}{1, 2, 3, 4}, []interface{}{1, 5, 3, 4, 6})
+	expected := []Edit{
+		{Kind: EditEqual, Left: 0, Right: 0, Value: 1},
+		{Kind: EditDelete, Left: 1, Right: -1, Value: 2},
+		{Kind: EditInsert, Left: -1, Right: 1, Value: 5},
+		{Kind: EditEqual, Left: 2, Right: 2, Value: 3},
+		{Kind: EditEqual, Left: 3, Right: 3, Value: 4},
+		{Kind: EditInsert, Left: -1, Right: 4, Value: 6},
+	}
+
+	actual := newLcs.EditScript()
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %#v, expected: %#v", actual, expected)
+	}
+}
+
+func TestHunks(t *testing.T) {
+	left := []interface{}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	right := []interface{}{0, 1, 20, 3, 4, 5, 6, 7, 9}
+	newLcs := New(left, right)
+
+	cases := []struct {
+		context int
+		hunks   [][4]int
+	}{
+		{context: 0, hunks: [][4]int{{2, 1, 2, 1}, {8, 1, 8, 0}}},
+		{context: 1, hunks: [][4]int{{1, 3, 1, 3}, {7, 3, 7, 2}}},
+		{context: 3, hunks: [][4]int{{0, 10, 0, 9}}},
+	}
+
+	for i, c := range cases {
+		hunks := newLcs.Hunks(c.context)
+		actual := make([][4]int, len(hunks))
+		for j, hunk := range hunks {
+			actual[j] = [4]int{hunk.LeftStart, hunk.LeftLength, hunk.RightStart, hunk.RightLength}
+		}
+		if !reflect.DeepEqual(actual, c.hunks) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.hunks)
+		}
+	}
+}
+
+func TestPatchFile(t *testing.T) {
+	oldTime := time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("", -8*60*60))
+	newTime := time.Date(2021, 12, 31, 23, 59, 59, 999999999, time.UTC)
+
+	actual := NewLines("a\nb\nc\n", "a\nB\nc").PatchFile("old.txt", "new.txt", oldTime, newTime)
+	expected := "--- old.txt\t2020-01-02 03:04:05.000000006 -0800\n" +
+		"+++ new.txt\t2021-12-31 23:59:59.999999999 +0000\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" a\n" +
+		"-b\n" +
+		"-c\n" +
+		"+B\n" +
+		"+c\n" +
+		"\\ No newline at end of file\n"
+	if actual != expected {
+		t.Errorf("actual: %q, expected: %q", actual, expected)
+	}
+
+	if patch := NewLines("a\n", "a\n").PatchFile("old.txt", "new.txt", oldTime, newTime); patch != "" {
+		t.Errorf("unexpected patch for the same texts: %q", patch)
+	}
+}
+
+func TestPatchFileApply(t *testing.T) {
+	if _, err := exec.LookPath("patch"); err != nil {
+		t.Skip("patch is not available")
+	}
+
+	cases := []struct {
+		old string
+		new string
+	}{
+		{old: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", new: "1\n2\nthree\n4\n5\n6\n7\n8\n10\n11\n"},
+		{old: "a\nb", new: "a\nb\nc\n"},
+		{old: "a\nb\n", new: "x\na\nb"},
+		{old: "", new: "new\nfile\n"},
+		{old: "a\nb\nc\n", new: ""},
+	}
+
+	for i, c := range cases {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(c.old), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		patch := NewLines(c.old, c.new).PatchFile("file.txt", "file.txt", time.Now(), time.Now())
+		if err := os.WriteFile(filepath.Join(dir, "file.patch"), []byte(patch), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := exec.Command("patch", "-p0", "-s", "-i", "file.patch")
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("test case %d failed to apply the patch: %s\n%s\n%s", i, err, output, patch)
+			continue
+		}
+
+		actual, err := os.ReadFile(filepath.Join(dir, "file.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(actual) != c.new {
+			t.Errorf("test case %d failed, actual: %q, expected: %q", i, actual, c.new)
+		}
+	}
+}
+
+func TestReverseEditScript(t *testing.T) {
+	newLcs := New([]interface{}{1, 2, 3}, []interface{}{4, 2, 5, 6})
+	expected := []Edit{
+		{Kind: EditDelete, Left: 0, Right: -1, Value: 4},
+		{Kind: EditInsert, Left: -1, Right: 0, Value: 1},
+		{Kind: EditEqual, Left: 1, Right: 1, Value: 2},
+		{Kind: EditDelete, Left: 2, Right: -1, Value: 5},
+		{Kind: EditDelete, Left: 3, Right: -1, Value: 6},
+		{Kind: EditInsert, Left: -1, Right: 2, Value: 3},
+	}
+	if actual := newLcs.ReverseEditScript(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %#v, expected: %#v", actual, expected)
+	}
+}
+
+func TestApply(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		left := randomInts(random, random.Intn(20), 5)
+		right := randomInts(random, random.Intn(20), 5)
+		newLcs := New(left, right)
+
+		applied, err := Apply(left, newLcs.EditScript())
+		if err != nil || !reflect.DeepEqual(applied, right) {
+			t.Errorf("test case %d failed to apply, actual: %v, expected: %v, err: %v", i, applied, right, err)
+			continue
+		}
+		reverted, err := Apply(applied, newLcs.ReverseEditScript())
+		if err != nil || !reflect.DeepEqual(reverted, left) {
+			t.Errorf("test case %d failed to revert, actual: %v, expected: %v, err: %v", i, reverted, left, err)
+		}
+	}
+
+	script := New([]interface{}{1, 2}, []interface{}{2, 3}).EditScript()
+	for i, left := range [][]interface{}{{1}, {1, 2, 3}} {
+		if _, err := Apply(left, script); err != ErrInvalidEditScript {
+			t.Errorf("test case %d failed, unexpected err: %v", i, err)
+		}
+	}
+}
+
+func TestNormalDiff(t *testing.T) {
+	cases := []struct {
+		left  string
+		right string
+		diff  string
+	}{
+		{
+			// the output of GNU diff
+			left:  "a\nb\nc\nd\ne\nf\ng\n",
+			right: "a\nB\nc\nx\ny\nd\nf\ng",
+			diff: "2c2\n< b\n---\n> B\n" +
+				"3a4,5\n> x\n> y\n" +
+				"5d6\n< e\n" +
+				"7c8\n< g\n---\n> g\n\\ No newline at end of file\n",
+		},
+		{
+			left:  "x\na\n",
+			right: "a\nz\n",
+			diff:  "1d0\n< x\n2a2\n> z\n",
+		},
+		{
+			left:  "a\nb\nc\n",
+			right: "d\ne\n",
+			diff:  "1,3c1,2\n< a\n< b\n< c\n---\n> d\n> e\n",
+		},
+		{
+			left:  "a\n",
+			right: "a\n",
+			diff:  "",
+		},
+	}
+
+	for i, c := range cases {
+		if actual := NewLines(c.left, c.right).NormalDiff(); actual != c.diff {
+			t.Errorf("test case %d failed, actual: %q, expected: %q", i, actual, c.diff)
+		}
+	}
+}
+
+func TestEditScriptLimited(t *testing.T) {
+	newLcs := New([]interface{}{1, 2, 3, 4, 5}, []interface{}{1, 6, 3, 7, 8})
+	script := newLcs.EditScript()
+
+	cases := []struct {
+		limit     int
+		truncated bool
+	}{
+		{limit: 0, truncated: true},
+		{limit: 3, truncated: true},
+		{limit: len(script) - 1, truncated: true},
+		{limit: len(script), truncated: false},
+		{limit: 100, truncated: false},
+		{limit: -1, truncated: true},
+	}
+
+	for i, c := range cases {
+		edits, truncated := newLcs.EditScriptLimited(c.limit)
+		expected := script[:min(max(c.limit, 0), len(script))]
+		if !reflect.DeepEqual(edits, expected) {
+			t.Errorf("test case %d failed at edits, actual: %v, expected: %v", i, edits, expected)
+		}
+		if truncated != c.truncated {
+			t.Errorf("test case %d failed at truncated, actual: %v, expected: %v", i, truncated, c.truncated)
+		}
+	}
+
+	if actual := newLcs.EditCount(); actual != 6 {
+		t.Errorf("failed at edit count, actual: %v, expected: %v", actual, 6)
+	}
+	if actual := newLcs.Length(); actual != 2 {
+		t.Errorf("failed at length, actual: %v, expected: %v", actual, 2)
+	}
+}
@@ -0,0 +1,56 @@
+package golcs
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+// DiffHash implements LCS.DiffHash()
+func (lcs *lcs) DiffHash() uint64 {
+	hash, _ := lcs.DiffHashContext(context.Background())
+	return hash
+}
+
+// DiffHashContext implements LCS.DiffHashContext()
+//
+// The hash is the 64-bit FNV-1a of Operations(): for each operation, a byte
+// of its OperationKind and the start of its range in Left and Right and its
+// Count as unsigned varints, and for an insert, each inserted element written
+// with %#v and a NUL byte, like the fingerprint of a checkpoint. The deleted
+// and retained elements are identified by their ranges only. FNV has a fixed
+// offset basis and no random seed, so the same diff hashes the same in every
+// process and every version of Go, as long as the elements are written the
+// same; an element written with an address, like a pointer, is not stable.
+// Two diffs of the same hash are equivalent but for the rare collisions, and
+// two different hashes are always different diffs.
+func (lcs *lcs) DiffHashContext(ctx context.Context) (uint64, error) {
+	operations, err := lcs.OperationsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	hash := fnv.New64a()
+	var encoded [binary.MaxVarintLen64]byte
+	write := func(value int) {
+		hash.Write(encoded[:binary.PutUvarint(encoded[:], uint64(value))])
+	}
+	x, y := 0, 0
+	for _, operation := range operations {
+		hash.Write([]byte{byte(operation.Kind)})
+		write(x)
+		write(y)
+		write(operation.Count)
+		for _, value := range operation.Values {
+			fmt.Fprintf(hash, "%#v\x00", value)
+		}
+		if operation.Kind != OperationInsert {
+			x += operation.Count
+		}
+		if operation.Kind != OperationDelete {
+			y += operation.Count
+		}
+	}
+	return hash.Sum64(), nil
+}
@@ -0,0 +1,36 @@
+package golcs
+
+import "testing"
+
+func TestDiffHash(t *testing.T) {
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+		same  []interface{}
+		other []interface{}
+	}{
+		// the same deletions of other elements are the same diff
+		{left: []interface{}{"a", "b", "c"}, right: []interface{}{"a", "c"}, same: []interface{}{"a", "x", "c"}, other: []interface{}{"a", "b"}},
+		{left: []interface{}{1, 2, 3}, right: []interface{}{1, 4, 3}, same: []interface{}{1, 2, 3}, other: []interface{}{1, "4", 3}},
+		{left: []interface{}{}, right: []interface{}{"a"}, same: []interface{}{}, other: []interface{}{"b"}},
+		{left: []interface{}{"a", "b"}, right: []interface{}{"b", "a"}, same: []interface{}{"a", "b"}, other: []interface{}{"a", "b"}},
+	}
+
+	for i, c := range cases {
+		hash := New(c.left, c.right).DiffHash()
+		if actual := New(c.left, c.right).DiffHash(); actual != hash {
+			t.Errorf("test case %d failed at identical, actual: %#x, expected: %#x", i, actual, hash)
+		}
+		if actual := New(c.same, c.right).DiffHash(); actual != hash {
+			t.Errorf("test case %d failed at same, actual: %#x, expected: %#x", i, actual, hash)
+		}
+		if actual := New(c.left, c.other).DiffHash(); actual == hash {
+			t.Errorf("test case %d failed at other, actual: %#x", i, actual)
+		}
+	}
+
+	// stable across runs
+	if actual, expected := NewLines("a\nb\nc\n", "a\nx\nc\n").DiffHash(), uint64(0xa4d117585fa02ff5); actual != expected {
+		t.Errorf("failed at stable, actual: %#x, expected: %#x", actual, expected)
+	}
+}
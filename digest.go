@@ -0,0 +1,52 @@
+package golcs
+
+// WithContentDigest compares elements by their digests, such as SHA-256 of
+// the contents of large blobs, instead of comparing the contents for every
+// pair of elements.
+//
+// The digest of each element of Left and Right is calculated once when the
+// calculator is created, after the transforms of the options, and kept for
+// its life, 32 bytes per element; with Prepare(), the digests of Left are
+// calculated for each Diff(). Two elements of different digests never match.
+// Two elements of the same digest match without being compared, which is
+// right as long as the digests do not collide; when an equality is given
+// with WithEqual() or another option changing it, the equality verifies every
+// pair of the same digest, so a collision costs one comparison and never a
+// wrong match.
+func WithContentDigest(digest func(interface{}) [32]byte) Option {
+	return func(lcs *lcs) {
+		lcs.contentDigest = digest
+	}
+}
+
+// useDigests makes the equality of WithContentDigest() only verify the
+// elements of the same digest, so it needs to know whether an equality is
+// given with the other options.
+func (lcs *lcs) useDigests() {
+	if lcs.contentDigest == nil {
+		return
+	}
+	if !lcs.customEqual {
+		// the digests decide
+		lcs.equal = func(a, b interface{}) bool {
+			return true
+		}
+	}
+	lcs.customEqual = true
+}
+
+// digest calculates the digests of WithContentDigest().
+func (lcs *lcs) digest() {
+	lcs.leftDigests, lcs.rightDigests = nil, nil
+	if lcs.contentDigest == nil {
+		return
+	}
+	lcs.leftDigests = make([][32]byte, len(lcs.leftKeys))
+	for x, key := range lcs.leftKeys {
+		lcs.leftDigests[x] = lcs.contentDigest(key)
+	}
+	lcs.rightDigests = make([][32]byte, len(lcs.rightKeys))
+	for y, key := range lcs.rightKeys {
+		lcs.rightDigests[y] = lcs.contentDigest(key)
+	}
+}
@@ -0,0 +1,86 @@
+package golcs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func sha256Digest(value interface{}) [32]byte {
+	return sha256.Sum256(value.([]byte))
+}
+
+func TestWithContentDigest(t *testing.T) {
+	blobs := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	left := []interface{}{blobs[0], blobs[1], blobs[2]}
+	right := []interface{}{blobs[1], blobs[2], blobs[3]}
+
+	calls := 0
+	newLcs := New(left, right, WithContentDigest(func(value interface{}) [32]byte {
+		calls++
+		return sha256Digest(value)
+	}))
+	if pairs, expected := newLcs.IndexPairs(), []IndexPair{{1, 0}, {2, 1}}; !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("failed at index pairs, actual: %v, expected: %v", pairs, expected)
+	}
+	newLcs.Table()
+	if calls != len(left)+len(right) {
+		t.Errorf("failed at digest calls, actual: %d, expected: %d", calls, len(left)+len(right))
+	}
+
+	// a digest colliding for every blob is verified by the equality
+	collision := WithContentDigest(func(interface{}) [32]byte { return [32]byte{} })
+	if length := New(left, right, collision).Length(); length != 3 {
+		t.Errorf("failed at unverified collision, actual: %d, expected: %d", length, 3)
+	}
+	verified := 0
+	verifier := WithEqual(func(a, b interface{}) bool {
+		verified++
+		return bytes.Equal(a.([]byte), b.([]byte))
+	})
+	if length := New(left, right, collision, verifier).Length(); length != 2 {
+		t.Errorf("failed at verified collision, actual: %d, expected: %d", length, 2)
+	}
+	if verified == 0 {
+		t.Errorf("failed at verification, the equality is not called")
+	}
+	verified = 0
+	if length := New(left, right, WithContentDigest(sha256Digest), verifier).Length(); length != 2 || verified != 2 {
+		t.Errorf("failed at verified digests, actual: %d, %d calls, expected: %d, %d calls", length, verified, 2, 2)
+	}
+
+	if length := Prepare(left, WithContentDigest(sha256Digest)).Diff(right).Length(); length != 2 {
+		t.Errorf("failed at prepared, actual: %d, expected: %d", length, 2)
+	}
+}
+
+func benchmarkLargeBlobs(b *testing.B, opts ...Option) {
+	random := rand.New(rand.NewSource(1))
+	// blobs of 64KiB which differ only at the end
+	blobs := make([][]byte, 20)
+	for i := range blobs {
+		blobs[i] = make([]byte, 64*1024)
+		blobs[i][len(blobs[i])-1] = byte(i)
+	}
+	left := make([]interface{}, 100)
+	right := make([]interface{}, 100)
+	for i := range left {
+		left[i] = blobs[random.Intn(len(blobs))]
+		right[i] = blobs[random.Intn(len(blobs))]
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(left, right, opts...).Length()
+	}
+}
+
+func BenchmarkLargeBlobs(b *testing.B) {
+	benchmarkLargeBlobs(b)
+}
+
+func BenchmarkLargeBlobsWithContentDigest(b *testing.B) {
+	benchmarkLargeBlobs(b, WithContentDigest(sha256Digest))
+}
@@ -0,0 +1,84 @@
+package golcs
+
+import "context"
+
+// WithMinimalDisplacement chooses, among the longest common subsequences, the
+// one with the least sum of |pair.Left - pair.Right| over IndexPairs(), so the
+// matched elements stay near their original positions. By default, the
+// backtracking prefers the pairs at the end of Left, which may match an
+// element with a copy far away when a near one would do as well. The option
+// changes only which pairs are chosen: Length() is the same. Ties in the sum
+// are broken by preferring a match, then skipping an element of Left, as the
+// default backtracking does. It needs the full memo table even with
+// WithRowCheckpoints().
+func WithMinimalDisplacement() Option {
+	return func(lcs *lcs) {
+		lcs.minimalDisplacement = true
+	}
+}
+
+// displacementIndexPairsContext backtracks the memo table along the paths of
+// the least displacement: cost[x][y] is the least sum of the displacements
+// of an LCS of left[:x] and right[:y].
+func (lcs *lcs) displacementIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	table, err := lcs.TableContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sizeX := len(lcs.left) + 1
+	sizeY := len(lcs.right) + 1
+	cost := make([][]int, sizeX)
+	for x := 0; x < sizeX; x++ {
+		cost[x] = make([]int, sizeY)
+	}
+	// matched[x][y] reports whether the diagonal is on a path of the least cost
+	matched := make([][]bool, sizeX)
+	for x := 0; x < sizeX; x++ {
+		matched[x] = make([]bool, sizeY)
+	}
+
+	for y := 1; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		for x := 1; x < sizeX; x++ {
+			best := -1
+			if table[x-1][y-1]+1 == table[x][y] && lcs.match(x-1, y-1) {
+				best = cost[x-1][y-1] + abs(x-y)
+				matched[x][y] = true
+			}
+			for _, prev := range [][2]int{{x - 1, y}, {x, y - 1}} {
+				if table[prev[0]][prev[1]] == table[x][y] && (best < 0 || cost[prev[0]][prev[1]] < best) {
+					best = cost[prev[0]][prev[1]]
+					matched[x][y] = false
+				}
+			}
+			cost[x][y] = best
+		}
+	}
+
+	pairs := make([]IndexPair, table[sizeX-1][sizeY-1])
+	for x, y := sizeX-1, sizeY-1; x > 0 && y > 0; {
+		if matched[x][y] {
+			pairs[table[x][y]-1] = IndexPair{Left: x - 1, Right: y - 1}
+			x--
+			y--
+		} else if table[x-1][y] == table[x][y] && cost[x-1][y] == cost[x][y] {
+			x--
+		} else {
+			y--
+		}
+	}
+	return pairs, nil
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
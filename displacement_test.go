@@ -0,0 +1,98 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithMinimalDisplacement(t *testing.T) {
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		pairs    []IndexPair
+		defaults []IndexPair
+	}{
+		{
+			left:     []interface{}{1, 2, 1},
+			right:    []interface{}{1},
+			pairs:    []IndexPair{{Left: 0, Right: 0}},
+			defaults: []IndexPair{{Left: 2, Right: 0}},
+		},
+		{
+			left:     []interface{}{"a", "b", "x", "a", "b"},
+			right:    []interface{}{"y", "a", "b"},
+			pairs:    []IndexPair{{Left: 0, Right: 1}, {Left: 1, Right: 2}},
+			defaults: []IndexPair{{Left: 3, Right: 1}, {Left: 4, Right: 2}},
+		},
+		{
+			left:     []interface{}{1, 2, 3},
+			right:    []interface{}{1, 2, 3},
+			pairs:    []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}, {Left: 2, Right: 2}},
+			defaults: []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}, {Left: 2, Right: 2}},
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right, WithMinimalDisplacement())
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+		if actual := New(c.left, c.right).IndexPairs(); !reflect.DeepEqual(actual, c.defaults) {
+			t.Errorf("test case %d failed at default pairs, actual: %v, expected: %v", i, actual, c.defaults)
+		}
+		if actual, expected := newLcs.Length(), len(c.defaults); actual != expected {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+}
+
+func TestWithMinimalDisplacementRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(9), 3)
+		right := randomInts(random, random.Intn(9), 3)
+
+		pairs := New(left, right, WithMinimalDisplacement()).IndexPairs()
+		if expected := New(left, right).Length(); len(pairs) != expected {
+			t.Fatalf("test case %d failed at length, actual: %v, expected: %v", i, len(pairs), expected)
+		}
+		for j, pair := range pairs {
+			if !reflect.DeepEqual(left[pair.Left], right[pair.Right]) || (j > 0 && (pair.Left <= pairs[j-1].Left || pair.Right <= pairs[j-1].Right)) {
+				t.Fatalf("test case %d failed at pair %d: %v", i, j, pairs)
+			}
+		}
+		if actual, expected := displacement(pairs), naiveMinimalDisplacement(left, right, 0, 0, len(pairs)); actual != expected {
+			t.Errorf("test case %d failed at displacement, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+}
+
+func displacement(pairs []IndexPair) int {
+	sum := 0
+	for _, pair := range pairs {
+		sum += abs(pair.Left - pair.Right)
+	}
+	return sum
+}
+
+// naiveMinimalDisplacement tries every common subsequence of the length in
+// left[x:] and right[y:], returning -1 when there is none.
+func naiveMinimalDisplacement(left, right []interface{}, x, y, length int) int {
+	if length == 0 {
+		return 0
+	}
+	best := -1
+	for i := x; i < len(left); i++ {
+		for j := y; j < len(right); j++ {
+			if !reflect.DeepEqual(left[i], right[j]) {
+				continue
+			}
+			rest := naiveMinimalDisplacement(left, right, i+1, j+1, length-1)
+			if rest >= 0 && (best < 0 || rest+abs(i-j) < best) {
+				best = rest + abs(i-j)
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,121 @@
+package golcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxEditGraphCells is the maximum number of the nodes of the full graph by EditGraphDOT().
+const maxEditGraphCells = 1024
+
+// EditGraphDOT implements LCS.EditGraphDOT()
+func (lcs *lcs) EditGraphDOT() string {
+	dot, _ := lcs.EditGraphDOTContext(context.Background())
+	return dot
+}
+
+// EditGraphDOTContext implements LCS.EditGraphDOTContext()
+//
+// The graph is the grid of the memo table in the Graphviz DOT language: the
+// node "x,y" is the cell after Left[:x] and Right[:y], an edge to the right
+// deletes Left[x], an edge down inserts Right[y] and a diagonal edge, labeled
+// with the element, matches Left[x] and Right[y]. Each row of nodes has the
+// same rank, so dot lays them out as the grid. The path of EditScript() from
+// "0,0" to the last node is drawn red and bold. When the grid has more than
+// 1024 nodes, only the path is written, as the graph of every match would be
+// too large to read, and the graph is labeled as elided.
+func (lcs *lcs) EditGraphDOTContext(ctx context.Context) (string, error) {
+	edits, err := lcs.EditScriptContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// the edges of the path by the node they start from
+	path := map[[2]int]EditKind{}
+	x, y := 0, 0
+	for _, edit := range edits {
+		path[[2]int{x, y}] = edit.Kind
+		x, y = editGraphStep(x, y, edit.Kind)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("digraph edit_graph {\n")
+	builder.WriteString("\tnode [shape=circle, width=0.1, label=\"\"];\n")
+	sizeX, sizeY := len(lcs.left)+1, len(lcs.right)+1
+	if sizeX*sizeY > maxEditGraphCells {
+		fmt.Fprintf(&builder, "\tlabel=\"only the path of the %dx%d grid\";\n", sizeX, sizeY)
+		x, y := 0, 0
+		for _, edit := range edits {
+			from := [2]int{x, y}
+			x, y = editGraphStep(x, y, edit.Kind)
+			lcs.writeEditGraphEdge(&builder, from, [2]int{x, y}, true)
+		}
+		builder.WriteString("}\n")
+		return builder.String(), nil
+	}
+
+	for y := 0; y < sizeY; y++ {
+		builder.WriteString("\t{rank=same;")
+		for x := 0; x < sizeX; x++ {
+			fmt.Fprintf(&builder, " \"%d,%d\";", x, y)
+		}
+		builder.WriteString("}\n")
+	}
+	for y := 0; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return "", ctx.Err()
+		default:
+			// nop
+		}
+		for x := 0; x < sizeX; x++ {
+			from := [2]int{x, y}
+			kind, onPath := path[from]
+			if x+1 < sizeX {
+				lcs.writeEditGraphEdge(&builder, from, [2]int{x + 1, y}, onPath && kind == EditDelete)
+			}
+			if y+1 < sizeY {
+				lcs.writeEditGraphEdge(&builder, from, [2]int{x, y + 1}, onPath && kind == EditInsert)
+			}
+			if x+1 < sizeX && y+1 < sizeY && lcs.match(x, y) {
+				lcs.writeEditGraphEdge(&builder, from, [2]int{x + 1, y + 1}, onPath && kind == EditEqual)
+			}
+		}
+	}
+	builder.WriteString("}\n")
+
+	if err := lcs.failed(); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// editGraphStep returns the node after an edit of the kind from x and y.
+func editGraphStep(x, y int, kind EditKind) (int, int) {
+	switch kind {
+	case EditEqual:
+		return x + 1, y + 1
+	case EditDelete:
+		return x + 1, y
+	default:
+		return x, y + 1
+	}
+}
+
+// writeEditGraphEdge writes an edge of EditGraphDOT(). A diagonal edge is
+// labeled with the element of Left.
+func (lcs *lcs) writeEditGraphEdge(builder *strings.Builder, from, to [2]int, onPath bool) {
+	attributes := []string{}
+	if to[0] > from[0] && to[1] > from[1] {
+		attributes = append(attributes, fmt.Sprintf("label=%q", fmt.Sprint(lcs.left[from[0]])))
+	}
+	if onPath {
+		attributes = append(attributes, "color=red", "penwidth=2")
+	}
+	fmt.Fprintf(builder, "\t\"%d,%d\" -> \"%d,%d\"", from[0], from[1], to[0], to[1])
+	if len(attributes) > 0 {
+		fmt.Fprintf(builder, " [%s]", strings.Join(attributes, ", "))
+	}
+	builder.WriteString(";\n")
+}
@@ -0,0 +1,40 @@
+package golcs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditGraphDOT(t *testing.T) {
+	expected := `digraph edit_graph {
+	node [shape=circle, width=0.1, label=""];
+	{rank=same; "0,0"; "1,0"; "2,0";}
+	{rank=same; "0,1"; "1,1"; "2,1";}
+	"0,0" -> "1,0" [color=red, penwidth=2];
+	"0,0" -> "0,1";
+	"1,0" -> "2,0";
+	"1,0" -> "1,1";
+	"1,0" -> "2,1" [label="b", color=red, penwidth=2];
+	"2,0" -> "2,1";
+	"0,1" -> "1,1";
+	"1,1" -> "2,1";
+}
+`
+	if actual := New([]interface{}{"a", "b"}, []interface{}{"b"}).EditGraphDOT(); actual != expected {
+		t.Errorf("failed at small graph, actual: %v, expected: %v", actual, expected)
+	}
+
+	left := []interface{}{}
+	for i := 0; i < 40; i++ {
+		left = append(left, i)
+	}
+	right := append([]interface{}{-1}, left...)
+	dot := New(left, right).EditGraphDOT()
+	if !strings.Contains(dot, "label=\"only the path of the 41x42 grid\"") {
+		t.Errorf("failed at elided graph, actual: %v", dot)
+	}
+	// the path of an insertion and 40 matches
+	if edges := strings.Count(dot, "->"); edges != 41 {
+		t.Errorf("failed at elided edges, actual: %d, expected: %d", edges, 41)
+	}
+}
@@ -0,0 +1,55 @@
+package golcs
+
+import "errors"
+
+// ErrInvalidExclusion is returned by the context aware methods when an index
+// given with WithExcludedLeft() or WithExcludedRight() is out of the array.
+var ErrInvalidExclusion = errors.New("golcs: excluded index out of range")
+
+// WithExcludedLeft excludes the elements of Left at the indices from the LCS,
+// for example positions already resolved by hand. An excluded element never
+// matches any element of Right, but it stays in Left: IndexPairs() and the
+// other results use the original indices, so no remapping is needed, and the
+// edit scripts delete the excluded elements. Indices out of Left make the
+// context aware methods return ErrInvalidExclusion, and the methods without a
+// context return empty results. The indices of multiple options are all
+// excluded.
+func WithExcludedLeft(indices []int) Option {
+	return func(lcs *lcs) {
+		lcs.excludedLeft = append(lcs.excludedLeft, indices...)
+	}
+}
+
+// WithExcludedRight is WithExcludedLeft() for the elements of Right.
+func WithExcludedRight(indices []int) Option {
+	return func(lcs *lcs) {
+		lcs.excludedRight = append(lcs.excludedRight, indices...)
+	}
+}
+
+// exclude marks the elements given by WithExcludedLeft() and WithExcludedRight().
+func (lcs *lcs) exclude() {
+	lcs.excludeLeft = lcs.exclusionMask(lcs.excludedLeft, len(lcs.left))
+	lcs.excludeRight = lcs.exclusionMask(lcs.excludedRight, len(lcs.right))
+}
+
+// exclusionMask reports whether each element of an array of the size is excluded.
+func (lcs *lcs) exclusionMask(indices []int, size int) []bool {
+	if len(indices) == 0 {
+		return nil
+	}
+	mask := make([]bool, size)
+	for _, index := range indices {
+		if index < 0 || index >= size {
+			lcs.fail(ErrInvalidExclusion)
+			continue
+		}
+		mask[index] = true
+	}
+	return mask
+}
+
+// excluded reports whether lcs.left[x] or lcs.right[y] is excluded.
+func (lcs *lcs) excluded(x, y int) bool {
+	return (lcs.excludeLeft != nil && lcs.excludeLeft[x]) || (lcs.excludeRight != nil && lcs.excludeRight[y])
+}
@@ -0,0 +1,71 @@
+package golcs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestWithExcluded(t *testing.T) {
+	left := []interface{}{1, 2, 3, 2, 4}
+	right := []interface{}{2, 3, 4, 2}
+
+	cases := []struct {
+		left  []int
+		right []int
+		pairs []IndexPair
+	}{
+		{
+			pairs: []IndexPair{{Left: 1, Right: 0}, {Left: 2, Right: 1}, {Left: 3, Right: 3}},
+		},
+		{
+			left:  []int{2},
+			pairs: []IndexPair{{Left: 1, Right: 0}, {Left: 3, Right: 3}},
+		},
+		{
+			left:  []int{1},
+			pairs: []IndexPair{{Left: 2, Right: 1}, {Left: 3, Right: 3}},
+		},
+		{
+			left:  []int{4},
+			right: []int{0},
+			pairs: []IndexPair{{Left: 2, Right: 1}, {Left: 3, Right: 3}},
+		},
+		{
+			left:  []int{0, 1, 2, 3, 4},
+			pairs: []IndexPair{},
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(left, right, WithExcludedLeft(c.left), WithExcludedRight(c.right))
+		pairs, err := newLcs.IndexPairsContext(context.Background())
+		if err != nil || !reflect.DeepEqual(pairs, c.pairs) {
+			t.Errorf("test case %d failed at pairs, actual: %v, %v, expected: %v", i, pairs, err, c.pairs)
+		}
+		if actual := newLcs.Length(); actual != len(c.pairs) {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, len(c.pairs))
+		}
+		if actual := Prepare(left, WithExcludedLeft(c.left), WithExcludedRight(c.right)).Diff(right).IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at prepared pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+	}
+}
+
+func TestWithExcludedOutOfRange(t *testing.T) {
+	left := []interface{}{1, 2}
+	right := []interface{}{1, 2}
+
+	for i, opt := range []Option{WithExcludedLeft([]int{2}), WithExcludedRight([]int{-1})} {
+		newLcs := New(left, right, opt)
+		if _, err := newLcs.IndexPairsContext(context.Background()); err != ErrInvalidExclusion {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, err, ErrInvalidExclusion)
+		}
+		if _, err := newLcs.LengthContext(context.Background()); err != ErrInvalidExclusion {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, err, ErrInvalidExclusion)
+		}
+		if actual := newLcs.Values(); actual != nil {
+			t.Errorf("test case %d failed at values, actual: %v, expected: nil", i, actual)
+		}
+	}
+}
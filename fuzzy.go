@@ -0,0 +1,109 @@
+package golcs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// fuzzyContextSize is the number of the unchanged elements around the changes
+// of a hunk which ApplyFuzzy() matches, the default of diff -u.
+const fuzzyContextSize = 3
+
+// HunkNotFoundError is returned by ApplyFuzzy when a hunk does not match
+// anywhere within the fuzz of its expected position.
+type HunkNotFoundError struct {
+	// Hunk is the index of the hunk among those of the script, from 0.
+	Hunk int
+	// LeftStart is the position of the hunk in the array the script was made from.
+	LeftStart int
+}
+
+func (err *HunkNotFoundError) Error() string {
+	return fmt.Sprintf("golcs: hunk %d at %d does not match within the fuzz", err.Hunk, err.LeftStart)
+}
+
+// ApplyFuzzy applies an edit script such as EditScript() to an array which
+// may differ from the Left the script was made from outside the changes, as
+// patch does with a drifted file.
+//
+// The script is split into hunks as Hunks(3) does, and each hunk is placed
+// where its elements of the original Left, the deleted ones and up to 3
+// unchanged ones around them, appear in left in the same order. A hunk is
+// expected at its original position shifted by the offset where the previous
+// hunk was placed, like many lines added above it, and is searched at the
+// expected position, then 1 before and 1 after it, then 2 before and so on up
+// to fuzz elements away, never overlapping the previous hunk. The elements of
+// left between the hunks are kept as they are. When a hunk is not found, it
+// returns a *HunkNotFoundError with the hunk, and nothing is applied. It
+// returns ErrInvalidEditScript when the EditEqual and EditDelete of the script
+// do not visit the indices of its Left in order. fuzz less than 0 is treated
+// as 0, which only applies the hunks at the expected positions.
+func ApplyFuzzy(left []interface{}, script []Edit, fuzz int) ([]interface{}, error) {
+	x := 0
+	for _, edit := range script {
+		switch edit.Kind {
+		case EditEqual, EditDelete:
+			if edit.Left != x {
+				return nil, ErrInvalidEditScript
+			}
+			x++
+		case EditInsert:
+		default:
+			return nil, ErrInvalidEditScript
+		}
+	}
+	fuzz = max(fuzz, 0)
+
+	result := make([]interface{}, 0, len(left))
+	cursor, offset := 0, 0
+	for i, hunk := range hunks(script, fuzzyContextSize) {
+		expected := hunk.LeftStart + offset
+		start := -1
+		for distance := 0; distance <= fuzz && start == -1; distance++ {
+			for _, candidate := range []int{expected - distance, expected + distance} {
+				if candidate >= cursor && hunkMatches(left, candidate, hunk) {
+					start = candidate
+					break
+				}
+			}
+		}
+		if start == -1 {
+			return nil, &HunkNotFoundError{Hunk: i, LeftStart: hunk.LeftStart}
+		}
+
+		result = append(result, left[cursor:start]...)
+		position := start
+		for _, edit := range hunk.Edits {
+			switch edit.Kind {
+			case EditEqual:
+				result = append(result, left[position])
+				position++
+			case EditDelete:
+				position++
+			case EditInsert:
+				result = append(result, edit.Value)
+			}
+		}
+		cursor, offset = position, start-hunk.LeftStart
+	}
+	return append(result, left[cursor:]...), nil
+}
+
+// hunkMatches reports whether the elements of the original Left in a hunk
+// appear in left from start.
+func hunkMatches(left []interface{}, start int, hunk Hunk) bool {
+	if start+hunk.LeftLength > len(left) {
+		return false
+	}
+	position := start
+	for _, edit := range hunk.Edits {
+		if edit.Kind == EditInsert {
+			continue
+		}
+		if !reflect.DeepEqual(left[position], edit.Value) {
+			return false
+		}
+		position++
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package golcs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApplyFuzzy(t *testing.T) {
+	base := make([]interface{}, 20)
+	for i := range base {
+		base[i] = i
+	}
+	// replace 5 with "five" and delete 15
+	modified := append(append(append([]interface{}{}, base[:5]...), "five"), base[6:15]...)
+	modified = append(modified, base[16:]...)
+	script := New(base, modified).EditScript()
+
+	cases := []struct {
+		left     []interface{}
+		fuzz     int
+		expected []interface{}
+	}{
+		// the original base
+		{base, 0, modified},
+		// 2 elements added at the start shift the hunks
+		{append([]interface{}{"a", "b"}, base...), 2, append([]interface{}{"a", "b"}, modified...)},
+		// an element added between the hunks shifts only the second one
+		{
+			append(append(append([]interface{}{}, base[:10]...), "x"), base[10:]...),
+			1,
+			append(append(append([]interface{}{}, modified[:10]...), "x"), modified[10:]...),
+		},
+		// 2 elements removed at the start shift the hunks back
+		{base[2:], 2, modified[2:]},
+	}
+
+	for i, cs := range cases {
+		actual, err := ApplyFuzzy(cs.left, script, cs.fuzz)
+		if err != nil || !reflect.DeepEqual(actual, cs.expected) {
+			t.Errorf("test case %d failed, actual: %v, %v, expected: %v", i, actual, err, cs.expected)
+		}
+	}
+
+	// 2 elements added at the start need a fuzz of 2
+	var notFound *HunkNotFoundError
+	_, err := ApplyFuzzy(append([]interface{}{"a", "b"}, base...), script, 1)
+	if !errors.As(err, &notFound) || notFound.Hunk != 0 || notFound.LeftStart != 2 {
+		t.Errorf("failed at not found, actual: %v", err)
+	}
+	// the context of the second hunk changed
+	changed := append([]interface{}{}, base...)
+	changed[14] = "changed"
+	_, err = ApplyFuzzy(changed, script, 5)
+	if !errors.As(err, &notFound) || notFound.Hunk != 1 {
+		t.Errorf("failed at changed context, actual: %v", err)
+	}
+	if _, err := ApplyFuzzy(base, []Edit{{Kind: EditDelete, Left: 1}}, 0); err != ErrInvalidEditScript {
+		t.Errorf("failed at invalid script, actual: %v, expected: %v", err, ErrInvalidEditScript)
+	}
+}
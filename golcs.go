@@ -4,15 +4,34 @@ package golcs
 
 import (
 	"context"
+	"io"
 	"reflect"
+	"sync"
+	"time"
 )
 
 // LCS is the interface to calculate the LCS of two arrays.
 type LCS interface {
+	// Table calculates the memo table of the LCS lengths of the prefixes of the two arrays.
+	Table() (table [][]int)
+	// TableContext is a context aware version of Table()
+	TableContext(ctx context.Context) ([][]int, error)
+	// SuffixTable calculates the memo table of the LCS lengths of the suffixes of the two arrays.
+	SuffixTable() (table [][]int)
+	// SuffixTableContext is a context aware version of SuffixTable()
+	SuffixTableContext(ctx context.Context) ([][]int, error)
+	// BacktrackPath calculates the cells of Table() visited while backtracking it for IndexPairs().
+	BacktrackPath() []IndexPair
+	// BacktrackPathContext is a context aware version of BacktrackPath()
+	BacktrackPathContext(ctx context.Context) ([]IndexPair, error)
 	// Values calculates the LCS value of the two arrays.
 	Values() (values []interface{})
 	// ValuesContext is a context aware version of Values()
 	ValuesContext(ctx context.Context) ([]interface{}, error)
+	// CanonicalValues calculates the LCS value of the two arrays in the forms compared, see WithCanonicalize().
+	CanonicalValues() []interface{}
+	// CanonicalValuesContext is a context aware version of CanonicalValues()
+	CanonicalValuesContext(ctx context.Context) ([]interface{}, error)
 	// IndexPairs calculates paris of indices which have the same value in LCS.
 	IndexPairs() (pairs []IndexPair)
 	// IndexPairsContext is a context aware version of IndexPairs()
@@ -21,6 +40,180 @@ type LCS interface {
 	Length() (length int)
 	// LengthContext is a context aware version of Length()
 	LengthContext(ctx context.Context) (int, error)
+	// LeftCoverage calculates the ratio of Left preserved in the LCS.
+	LeftCoverage() float64
+	// LeftCoverageContext is a context aware version of LeftCoverage()
+	LeftCoverageContext(ctx context.Context) (float64, error)
+	// RightCoverage calculates the ratio of Right preserved in the LCS.
+	RightCoverage() float64
+	// RightCoverageContext is a context aware version of RightCoverage()
+	RightCoverageContext(ctx context.Context) (float64, error)
+	// OverlapRatio calculates the ratio of the larger of Left and Right preserved in the LCS.
+	OverlapRatio() float64
+	// OverlapRatioContext is a context aware version of OverlapRatio()
+	OverlapRatioContext(ctx context.Context) (float64, error)
+	// IsInsertOnly reports whether Left is a subsequence of Right, so Right only has elements inserted.
+	IsInsertOnly() bool
+	// IsInsertOnlyContext is a context aware version of IsInsertOnly()
+	IsInsertOnlyContext(ctx context.Context) (bool, error)
+	// IsDeleteOnly reports whether Right is a subsequence of Left, so Left only has elements deleted.
+	IsDeleteOnly() bool
+	// IsDeleteOnlyContext is a context aware version of IsDeleteOnly()
+	IsDeleteOnlyContext(ctx context.Context) (bool, error)
+	// EditScript calculates the edits to transform Left into Right.
+	EditScript() []Edit
+	// EditScriptContext is a context aware version of EditScript()
+	EditScriptContext(ctx context.Context) ([]Edit, error)
+	// StableDiff calculates a minimal edit script canonicalized to be the same when Right is rebuilt with it and diffed again.
+	StableDiff() []Edit
+	// StableDiffContext is a context aware version of StableDiff()
+	StableDiffContext(ctx context.Context) ([]Edit, error)
+	// Opcodes calculates the ranges of Left and Right to keep, replace, delete and insert.
+	Opcodes() []Opcode
+	// OpcodesContext is a context aware version of Opcodes()
+	OpcodesContext(ctx context.Context) ([]Opcode, error)
+	// EditScriptLimited calculates up to limit edits of EditScript() and whether it has more.
+	EditScriptLimited(limit int) (edits []Edit, truncated bool)
+	// EditScriptLimitedContext is a context aware version of EditScriptLimited()
+	EditScriptLimitedContext(ctx context.Context, limit int) (edits []Edit, truncated bool, err error)
+	// EditCount calculates the number of deletions and insertions in EditScript().
+	EditCount() int
+	// EditCountContext is a context aware version of EditCount()
+	EditCountContext(ctx context.Context) (int, error)
+	// Operations calculates the retain, insert and delete operations to transform Left into Right.
+	Operations() []Operation
+	// OperationsContext is a context aware version of Operations()
+	OperationsContext(ctx context.Context) ([]Operation, error)
+	// Spans calculates the retain, insert and delete spans over the document evolving from Left into Right.
+	Spans() []Span
+	// SpansContext is a context aware version of Spans()
+	SpansContext(ctx context.Context) ([]Span, error)
+	// BinaryDelta encodes Operations() of bytes as a compact delta.
+	BinaryDelta() []byte
+	// BinaryDeltaContext is a context aware version of BinaryDelta()
+	BinaryDeltaContext(ctx context.Context) ([]byte, error)
+	// PatchSizeEstimate estimates the size in bytes of the delta to transform Left into Right.
+	PatchSizeEstimate() int
+	// PatchSizeEstimateContext is a context aware version of PatchSizeEstimate()
+	PatchSizeEstimateContext(ctx context.Context) (int, error)
+	// DiffHash hashes Operations() into a value which is the same in every process for the same diff.
+	DiffHash() uint64
+	// DiffHashContext is a context aware version of DiffHash()
+	DiffHashContext(ctx context.Context) (uint64, error)
+	// MoveMap detects the blocks deleted from Left and inserted in Right as is.
+	MoveMap() []BlockMove
+	// MoveMapContext is a context aware version of MoveMap()
+	MoveMapContext(ctx context.Context) ([]BlockMove, error)
+	// DiffByRecords splits Left and Right into records at separator and calculates the edit script of each pair of records.
+	DiffByRecords(separator interface{}) [][]Edit
+	// DiffByRecordsContext is a context aware version of DiffByRecords()
+	DiffByRecordsContext(ctx context.Context, separator interface{}) ([][]Edit, error)
+	// DiffStream sends the edits of EditScript() to a channel.
+	DiffStream(ctx context.Context) (<-chan Edit, <-chan error)
+	// ReverseEditScript calculates the edits to transform Right into Left.
+	ReverseEditScript() []Edit
+	// ReverseEditScriptContext is a context aware version of ReverseEditScript()
+	ReverseEditScriptContext(ctx context.Context) ([]Edit, error)
+	// KeyedDiff calculates the changes of elements matched by their keys.
+	KeyedDiff() []KeyedEdit
+	// KeyedDiffContext is a context aware version of KeyedDiff()
+	KeyedDiffContext(ctx context.Context) ([]KeyedEdit, error)
+	// Hunks groups the changes in EditScript() with contextSize unchanged elements around them.
+	Hunks(contextSize int) []Hunk
+	// HunksContext is a context aware version of Hunks()
+	HunksContext(ctx context.Context, contextSize int) ([]Hunk, error)
+	// DiffSections groups Hunks() into sections separated by gapThreshold unchanged elements or more.
+	DiffSections(contextSize, gapThreshold int) []DiffSection
+	// DiffSectionsContext is a context aware version of DiffSections()
+	DiffSectionsContext(ctx context.Context, contextSize, gapThreshold int) ([]DiffSection, error)
+	// LocalDiff calculates the edits of the elements of Left within radius of leftIndex, diffing only the region around them.
+	LocalDiff(leftIndex, radius int) []Edit
+	// LocalDiffContext is a context aware version of LocalDiff()
+	LocalDiffContext(ctx context.Context, leftIndex, radius int) ([]Edit, error)
+	// Matches calculates the groups of elements matched together, which may match one element with several with WithManyToOne().
+	Matches() []Match
+	// MatchesContext is a context aware version of Matches()
+	MatchesContext(ctx context.Context) ([]Match, error)
+	// RenderHunks formats Hunks() as the headers and the lines of a unified diff.
+	RenderHunks(contextSize int) []RenderHunk
+	// RenderHunksContext is a context aware version of RenderHunks()
+	RenderHunksContext(ctx context.Context, contextSize int) ([]RenderHunk, error)
+	// NormalDiff formats the changes in the normal format of diff.
+	NormalDiff() string
+	// PatchFile formats the changes as a unified diff patch of files.
+	PatchFile(oldName, newName string, oldTime, newTime time.Time) string
+	// WriteColorUnified writes the changes as a unified diff with optional colors to w.
+	WriteColorUnified(w io.Writer, contextSize int, colorize ColorMode) error
+	// WriteColorUnifiedContext is a context aware version of WriteColorUnified()
+	WriteColorUnifiedContext(ctx context.Context, w io.Writer, contextSize int, colorize ColorMode) error
+	// AlignmentRows lays out the elements of Left and Right in two rows of the same length with gap in the holes.
+	AlignmentRows(gap interface{}) (topRow, bottomRow []interface{})
+	// Partition calculates the LCS value and the elements of Left and Right not in it.
+	Partition() (common []interface{}, onlyLeft []interface{}, onlyRight []interface{})
+	// PartitionContext is a context aware version of Partition()
+	PartitionContext(ctx context.Context) (common []interface{}, onlyLeft []interface{}, onlyRight []interface{}, err error)
+	// Checkpoint encodes the progress of the memo table to resume it with ResumeFromCheckpoint().
+	Checkpoint() []byte
+	// HunkDensity counts the elements of Left not in the LCS in each of buckets equal ranges of Left.
+	HunkDensity(buckets int) []int
+	// HunkDensityContext is a context aware version of HunkDensity()
+	HunkDensityContext(ctx context.Context, buckets int) ([]int, error)
+	// EditGraphDOT renders the edit graph of the memo table with the path of EditScript() as Graphviz DOT.
+	EditGraphDOT() string
+	// EditGraphDOTContext is a context aware version of EditGraphDOT()
+	EditGraphDOTContext(ctx context.Context) (string, error)
+	// WriteHeatmap writes the memo table as a PNG image.
+	WriteHeatmap(w io.Writer) error
+	// MinHashSimilarity estimates the Jaccard index of the k-grams of the two arrays.
+	MinHashSimilarity(numHashes, k int) float64
+	// Ratio calculates the similarity of the two arrays as 2*M/T with the LCS length M and the total size T.
+	Ratio() float64
+	// RatioContext is a context aware version of Ratio()
+	RatioContext(ctx context.Context) (float64, error)
+	// QuickRatio calculates an upper bound of Ratio() quickly.
+	QuickRatio() float64
+	// HunkRatios calculates the similarity ratio of each hunk in Hunks().
+	HunkRatios(contextSize int) []float64
+	// HunkRatiosContext is a context aware version of HunkRatios()
+	HunkRatiosContext(ctx context.Context, contextSize int) ([]float64, error)
+	// LongestMonotonicRun finds the longest run of index pairs contiguous in Right.
+	LongestMonotonicRun() []IndexPair
+	// LongestMonotonicRunContext is a context aware version of LongestMonotonicRun()
+	LongestMonotonicRunContext(ctx context.Context) ([]IndexPair, error)
+	// CopyBlocks finds the runs of index pairs contiguous in both Left and Right.
+	CopyBlocks() []CopyBlock
+	// CopyBlocksContext is a context aware version of CopyBlocks()
+	CopyBlocksContext(ctx context.Context) ([]CopyBlock, error)
+	// AnnotatedPairs calculates IndexPairs() with the number of the neighbors of each pair which match.
+	AnnotatedPairs() []AnnotatedPair
+	// AnnotatedPairsContext is a context aware version of AnnotatedPairs()
+	AnnotatedPairsContext(ctx context.Context) ([]AnnotatedPair, error)
+	// ValueRuns calculates the LCS values grouped into the runs contiguous in both Left and Right.
+	ValueRuns() [][]interface{}
+	// ValueRunsContext is a context aware version of ValueRuns()
+	ValueRunsContext(ctx context.Context) ([][]interface{}, error)
+	// WindowedLengths calculates the LCS length of Left and each window sliding over Right.
+	WindowedLengths(window, step int) []int
+	// WindowedLengthsContext is a context aware version of WindowedLengths()
+	WindowedLengthsContext(ctx context.Context, window, step int) ([]int, error)
+	// SensitivityByIndex calculates the change of the length when each element of Left is removed.
+	SensitivityByIndex() []int
+	// SensitivityByIndexContext is a context aware version of SensitivityByIndex()
+	SensitivityByIndexContext(ctx context.Context) ([]int, error)
+	// Optimal reports whether IndexPairs() is a longest common subsequence found within WithBudget().
+	Optimal() bool
+	// Truncated reports whether WithMaxPairs() dropped pairs from IndexPairs().
+	Truncated() bool
+	// DisplayColumns calculates the display columns where the elements of Left and Right start.
+	DisplayColumns() (left, right []int)
+	// WeightedScore calculates the total weight of IndexPairs() by the rarity of the elements.
+	WeightedScore() float64
+	// WeightedScoreContext is a context aware version of WeightedScore()
+	WeightedScoreContext(ctx context.Context) (float64, error)
+	// SecondBestLength calculates the length of the longest common subsequence other than IndexPairs().
+	SecondBestLength() int
+	// SecondBestLengthContext is a context aware version of SecondBestLength()
+	SecondBestLengthContext(ctx context.Context) (int, error)
 	// Left returns one of the two arrays to be compared.
 	Left() []interface{}
 	// Right returns the other of the two arrays to be compared.
@@ -36,21 +229,119 @@ type IndexPair struct {
 type lcs struct {
 	left  []interface{}
 	right []interface{}
+	/* options */
+	opts           []Option
+	equal          func(a, b interface{}) bool
+	customEqual    bool
+	transforms     []func(interface{}) interface{}
+	rowCheckpoints int
+	noCache        bool
+	preferReplace  bool
+	/* see WithRarityWeighting() */
+	rarityWeighting bool
+	/* see WithMonotoneAttribute() */
+	monotoneAttribute func(interface{}) float64
+	/* see WithManyToOne() */
+	manyToOne bool
+	matches   []Match
+	/* see WithScoredMatch() */
+	scoredMatch func(a, b interface{}) (bool, float64)
+	/* see WithHuntSzymanski() */
+	huntSzymanski bool
+	positionIndex *positionIndex
+	/* see WithPatience() */
+	patience bool
+	/* see WithBudget() */
+	budget         time.Duration
+	budgetExceeded bool
+	/* see WithMinimalDisplacement() */
+	minimalDisplacement bool
+	/* see WithMaxPairs() */
+	maxPairs       int
+	limitPairs     bool
+	pairsTruncated bool
+	pairsLength    int
+	/* see WithMatchSelector() */
+	matchSelector func(leftIdx int, candidates []int) int
+	/* see WithMaxGap() */
+	maxGap       int
+	constrainGap bool
+	/* see WithExcludedLeft() and WithExcludedRight() */
+	excludedLeft  []int
+	excludedRight []int
+	excludeLeft   []bool
+	excludeRight  []bool
+	/* see WithAsyncEqual() */
+	asyncEqual       func(ctx context.Context, a, b interface{}) (bool, error)
+	asyncConcurrency int
+	asyncMatches     []bool
+	/* see WithContentDigest() */
+	contentDigest func(interface{}) [32]byte
+	leftDigests   [][32]byte
+	rightDigests  [][32]byte
+	/* see WithLengthRatio() */
+	lengthRatio  float64
+	leftLengths  []int
+	rightLengths []int
+	/* see WithBloomPrefilter() */
+	bloomPrefilter bool
+	fingerprint    func(interface{}) uint64
+	maybeInRight   []bool
+	/* the first error making the results invalid, see fail(); it also
+	guards the decisions of WithComparisonTimeout() */
+	failure     error
+	failureLock sync.Mutex
+	/* see WithComparisonTimeout() */
+	comparisonTimeout time.Duration
+	onTimeout         func(x, y int)
+	comparisonSlots   chan struct{}
+	decided           []uint64
+	decisions         []uint64
+	/* IDs of elements given by PreparedLeft */
+	preparedLeft  []int
+	preparedRight []int
+	/* see WithComparisonCache() */
+	comparisonCache map[[2]int]bool
+	leftIDs         []int
+	rightIDs        []int
+	/* see WithTabWidth() */
+	tabWidth int
+	/* used by NewLines() */
+	normalizeNewlines bool
+	/* left and right with transforms applied, given to equal */
+	leftKeys  []interface{}
+	rightKeys []interface{}
 	/* for caching */
 	table      [][]int
 	indexPairs []IndexPair
 	values     []interface{}
+	/* the memo table from the end of the arrays */
+	suffixTable [][]int
+	/* the memo table filled up to partialRows in y, kept on cancellation */
+	partialTable [][]int
+	partialRows  int
 }
 
 // New creates a new LCS calculator from two arrays.
-func New(left, right []interface{}) LCS {
-	return &lcs{
+// Elements are compared with reflect.DeepEqual unless an Option changes it.
+func New(left, right []interface{}, opts ...Option) LCS {
+	lcs := &lcs{
 		left:       left,
 		right:      right,
+		equal:      reflect.DeepEqual,
 		table:      nil,
 		indexPairs: nil,
 		values:     nil,
 	}
+	for _, opt := range opts {
+		opt(lcs)
+	}
+	lcs.useDigests()
+	lcs.opts = opts
+	lcs.leftKeys = lcs.keys(left)
+	lcs.rightKeys = lcs.keys(right)
+	lcs.setup()
+	return lcs
 }
 
 // Table implements LCS.Table()
@@ -64,31 +355,48 @@ func (lcs *lcs) TableContext(ctx context.Context) ([][]int, error) {
 	if lcs.table != nil {
 		return lcs.table, nil
 	}
+	if err := lcs.compareAll(ctx); err != nil {
+		return nil, err
+	}
 
 	sizeX := len(lcs.left) + 1
 	sizeY := len(lcs.right) + 1
 
-	table := make([][]int, sizeX)
-	for x := 0; x < sizeX; x++ {
-		table[x] = make([]int, sizeY)
+	// resume the calculation canceled before if any
+	table, start := lcs.partialTable, lcs.partialRows
+	if table == nil {
+		table = make([][]int, sizeX)
+		for x := 0; x < sizeX; x++ {
+			table[x] = make([]int, sizeY)
+		}
+		start = 1
 	}
 
-	for y := 1; y < sizeY; y++ {
+	for y := start; y < sizeY; y++ {
 		select { // check in each y to save some time
 		case <-ctx.Done():
+			lcs.partialTable, lcs.partialRows = table, y
 			return nil, ctx.Err()
 		default:
 			// nop
 		}
+		if err := lcs.failed(); err != nil {
+			lcs.partialTable, lcs.partialRows = nil, 0
+			return nil, err
+		}
 		for x := 1; x < sizeX; x++ {
 			increment := 0
-			if reflect.DeepEqual(lcs.left[x-1], lcs.right[y-1]) {
+			if lcs.match(x-1, y-1) {
 				increment = 1
 			}
 			table[x][y] = max(table[x-1][y-1]+increment, table[x-1][y], table[x][y-1])
 		}
 	}
 
+	lcs.partialTable, lcs.partialRows = nil, 0
+	if err := lcs.failed(); err != nil {
+		return nil, err
+	}
 	lcs.table = table
 	return table, nil
 }
@@ -100,16 +408,56 @@ func (lcs *lcs) Length() int {
 }
 
 // LengthContext Table implements LCS.LengthContext()
+//
+// The length is calculated with a single row of the memo table over the
+// shorter array, so it takes O(min(m,n)) memory, without touching the table
+// when it is not calculated yet. With WithMaxGap(), WithMonotoneAttribute(),
+// WithScoredMatch(), WithManyToOne(), WithBudget(), WithRarityWeighting() or
+// WithPatience(), it is the number of the pairs found by them instead, which
+// takes the time and memory of IndexPairs() with them rather than
+// O(min(m,n)). With WithManyToOne(), it is the number of the groups of
+// Matches(), which is the LCS length as the scoring never trades a group for
+// the extensions of the runs.
 func (lcs *lcs) LengthContext(ctx context.Context) (int, error) {
+	if err := lcs.compareAll(ctx); err != nil {
+		return 0, err
+	}
+	if lcs.gapConstrained() || lcs.monotoneAttribute != nil || lcs.scoredMatch != nil || lcs.manyToOne || lcs.budget > 0 || lcs.rarityWeighting || lcs.patience {
+		if _, err := lcs.IndexPairsContext(ctx); err != nil {
+			return 0, err
+		}
+		return lcs.pairsLength, nil
+	}
+	var length int
+	var err error
 	if len(lcs.right) > len(lcs.left) {
-		lcs.left, lcs.right = lcs.right, lcs.left
+		length, err = lengthContext(ctx, len(lcs.right), len(lcs.left), func(i, j int) bool {
+			return lcs.match(j, i)
+		})
+	} else {
+		length, err = lengthContext(ctx, len(lcs.left), len(lcs.right), lcs.match)
+	}
+	if err == nil {
+		err = lcs.failed()
+	}
+	if err != nil {
+		return 0, err
 	}
-	return lcs.lengthContext(ctx)
+	return length, nil
 }
 
-func (lcs *lcs) lengthContext(ctx context.Context) (int, error) {
-	m := len(lcs.left)
-	n := len(lcs.right)
+// LengthLinearSpace calculates the LCS length of two arrays in O(min(m,n))
+// memory for a Left of size m and a Right of size n. It gives only the length:
+// the pairs and the values need the memo table of O(mn) memory, or
+// WithRowCheckpoints() for less. The arrays are never modified.
+func LengthLinearSpace(left, right []interface{}, opts ...Option) int {
+	length, _ := New(left, right, opts...).LengthContext(context.Background())
+	return length
+}
+
+// lengthContext calculates the LCS length of two arrays of the size m and n,
+// where match(i, j) reports whether the i-th and j-th elements are the same.
+func lengthContext(ctx context.Context, m, n int, match func(i, j int) bool) (int, error) {
 
 	// allocate storage for one-dimensional array `curr`
 	prev := 0
@@ -128,7 +476,7 @@ func (lcs *lcs) lengthContext(ctx context.Context) (int, error) {
 			backup := curr[j]
 			if i == 0 || j == 0 {
 				curr[j] = 0
-			} else if reflect.DeepEqual(lcs.left[i-1], lcs.right[j-1]) {
+			} else if match(i-1, j-1) {
 				// if the current character of `X` and `Y` matches
 				curr[j] = prev + 1
 			} else {
@@ -153,16 +501,92 @@ func (lcs *lcs) IndexPairsContext(ctx context.Context) ([]IndexPair, error) {
 	if lcs.indexPairs != nil {
 		return lcs.indexPairs, nil
 	}
+	if err := lcs.compareAll(ctx); err != nil {
+		return nil, err
+	}
 
-	table, err := lcs.TableContext(ctx)
+	var pairs []IndexPair
+	var err error
+	length := -1
+	if lcs.gapConstrained() {
+		pairs, err = lcs.maxGapIndexPairsContext(ctx)
+	} else if lcs.monotoneAttribute != nil {
+		pairs, err = lcs.monotoneIndexPairsContext(ctx)
+	} else if lcs.scoredMatch != nil {
+		pairs, err = lcs.scoredIndexPairsContext(ctx)
+	} else if lcs.manyToOne {
+		pairs, err = lcs.manyToOneIndexPairsContext(ctx)
+	} else if lcs.rarityWeighting {
+		pairs, err = lcs.rarityIndexPairsContext(ctx)
+	} else if lcs.patience {
+		pairs, err = lcs.patienceIndexPairsContext(ctx)
+	} else if lcs.budget > 0 {
+		pairs, err = lcs.myersIndexPairsContext(ctx)
+	} else if lcs.minimalDisplacement {
+		pairs, err = lcs.displacementIndexPairsContext(ctx)
+	} else if lcs.matchSelector != nil {
+		pairs, err = lcs.selectorIndexPairsContext(ctx)
+	} else if lcs.huntSzymanski {
+		pairs, err = lcs.huntSzymanskiIndexPairsContext(ctx)
+	} else if lcs.rowCheckpoints > 0 {
+		pairs, err = lcs.rowCheckpointIndexPairsContext(ctx, lcs.rowCheckpoints)
+	} else {
+		limit := -1
+		if lcs.limitPairs {
+			limit = lcs.maxPairs
+		}
+		pairs, length, err = lcs.tableIndexPairsContext(ctx, limit)
+	}
+	if err == nil {
+		err = lcs.failed()
+	}
 	if err != nil {
 		return nil, err
 	}
+	if length < 0 {
+		length = len(pairs)
+	}
 
-	pairs := make([]IndexPair, table[len(table)-1][len(table[0])-1])
+	pairs = lcs.truncatePairs(pairs, length)
+	lcs.indexPairs = pairs
+	if lcs.noCache {
+		lcs.release()
+	}
+	return pairs, nil
+}
+
+// release drops the states of O(mn) memory for WithNoCache().
+func (lcs *lcs) release() {
+	lcs.table, lcs.suffixTable = nil, nil
+	lcs.partialTable, lcs.partialRows = nil, 0
+	lcs.asyncMatches = nil
+	if lcs.comparisonCache != nil {
+		lcs.comparisonCache = map[[2]int]bool{}
+	}
+	lcs.failureLock.Lock()
+	lcs.decided, lcs.decisions = nil, nil
+	lcs.failureLock.Unlock()
+}
+
+// tableIndexPairsContext backtracks the full memo table to find the pairs and
+// their length. Only the first limit pairs are kept unless limit < 0.
+func (lcs *lcs) tableIndexPairsContext(ctx context.Context, limit int) ([]IndexPair, int, error) {
+	table, err := lcs.TableContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	length := table[len(table)-1][len(table[0])-1]
+	size := length
+	if limit >= 0 {
+		size = min(size, limit)
+	}
+	pairs := make([]IndexPair, size)
 	for x, y := len(lcs.left), len(lcs.right); x > 0 && y > 0; {
-		if reflect.DeepEqual(lcs.left[x-1], lcs.right[y-1]) {
-			pairs[table[x][y]-1] = IndexPair{Left: x - 1, Right: y - 1}
+		if lcs.match(x-1, y-1) {
+			if i := table[x][y] - 1; i < size {
+				pairs[i] = IndexPair{Left: x - 1, Right: y - 1}
+			}
 			x--
 			y--
 		} else {
@@ -174,8 +598,7 @@ func (lcs *lcs) IndexPairsContext(ctx context.Context) ([]IndexPair, error) {
 		}
 	}
 
-	lcs.indexPairs = pairs
-	return pairs, nil
+	return pairs, length, nil
 }
 
 // Values Table implements LCS.Values()
@@ -214,6 +637,79 @@ func (lcs *lcs) Right() []interface{} {
 	return lcs.right
 }
 
+// setup prepares the states depending on the arrays given to New().
+func (lcs *lcs) setup() {
+	lcs.failure = nil
+	lcs.exclude()
+	lcs.digest()
+	lcs.measure()
+	lcs.filterLeft()
+}
+
+// match reports whether lcs.left[x] and lcs.right[y] are the same.
+func (lcs *lcs) match(x, y int) bool {
+	if lcs.excluded(x, y) || (lcs.maybeInRight != nil && !lcs.maybeInRight[x]) {
+		return false
+	}
+	if lcs.leftDigests != nil && lcs.leftDigests[x] != lcs.rightDigests[y] {
+		return false
+	}
+	if lcs.leftLengths != nil && lcs.lengthsDiffer(x, y) {
+		return false
+	}
+	if lcs.preparedLeft != nil {
+		return lcs.matchPrepared(x, y)
+	}
+	if lcs.comparisonCache != nil {
+		return lcs.matchWithCache(x, y)
+	}
+	return lcs.compare(x, y)
+}
+
+// compare calls the equality for lcs.left[x] and lcs.right[y].
+func (lcs *lcs) compare(x, y int) bool {
+	if lcs.asyncMatches != nil {
+		return lcs.asyncMatches[y*len(lcs.left)+x]
+	}
+	if lcs.comparisonTimeout > 0 {
+		return lcs.matchWithTimeout(x, y)
+	}
+	return lcs.equal(lcs.leftKeys[x], lcs.rightKeys[y])
+}
+
+// fail records err to be returned by the context aware methods unless an
+// error is recorded already, such as a failure of the equality given with
+// WithEqualErr() or invalid options.
+func (lcs *lcs) fail(err error) {
+	lcs.failureLock.Lock()
+	defer lcs.failureLock.Unlock()
+	if lcs.failure == nil {
+		lcs.failure = err
+	}
+}
+
+// failed returns the error recorded by fail() if any.
+func (lcs *lcs) failed() error {
+	lcs.failureLock.Lock()
+	defer lcs.failureLock.Unlock()
+	return lcs.failure
+}
+
+// keys applies the transforms to the elements of an array to compare them.
+func (lcs *lcs) keys(values []interface{}) []interface{} {
+	if len(lcs.transforms) == 0 {
+		return values
+	}
+	keys := make([]interface{}, len(values))
+	for i, value := range values {
+		for _, transform := range lcs.transforms {
+			value = transform(value)
+		}
+		keys[i] = value
+	}
+	return keys
+}
+
 func max(first int, rest ...int) int {
 	maxValue := first
 	for _, value := range rest {
@@ -223,3 +719,13 @@ func max(first int, rest ...int) int {
 	}
 	return maxValue
 }
+
+func min(first int, rest ...int) int {
+	minValue := first
+	for _, value := range rest {
+		if value < minValue {
+			minValue = value
+		}
+	}
+	return minValue
+}
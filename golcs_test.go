@@ -2,6 +2,7 @@ package golcs
 
 import (
 	"context"
+	"math/rand"
 	"reflect"
 	"testing"
 	"time"
@@ -117,3 +118,60 @@ func TestContextCancel(t *testing.T) {
 		t.Fatalf("unexpected err: %s", err)
 	}
 }
+
+func TestLengthLinearSpace(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+		leftCopy := append([]interface{}{}, left...)
+		rightCopy := append([]interface{}{}, right...)
+
+		expected := len(New(left, right).IndexPairs())
+		if actual := LengthLinearSpace(left, right); actual != expected {
+			t.Errorf("test case %d failed, actual: %d, expected: %d", i, actual, expected)
+		}
+		if !reflect.DeepEqual(left, leftCopy) || !reflect.DeepEqual(right, rightCopy) {
+			t.Errorf("test case %d failed, the arrays are modified", i)
+		}
+	}
+}
+
+func TestLengthKeepsArrays(t *testing.T) {
+	left := []interface{}{1, 2}
+	right := []interface{}{1, 2, 3}
+	newLcs := New(left, right)
+	newLcs.Length()
+	if !reflect.DeepEqual(newLcs.Left(), left) || !reflect.DeepEqual(newLcs.Right(), right) {
+		t.Errorf("the arrays are swapped, left: %v, right: %v", newLcs.Left(), newLcs.Right())
+	}
+	expected := []IndexPair{{0, 0}, {1, 1}}
+	if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at index pairs, actual: %v, expected: %v", actual, expected)
+	}
+}
+
+func BenchmarkLengthLinearSpace(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 1000, 10)
+	right := randomInts(random, 1000, 10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		LengthLinearSpace(left, right)
+	}
+}
+
+func BenchmarkLengthTable(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 1000, 10)
+	right := randomInts(random, 1000, 10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		table := New(left, right).(*lcs).Table()
+		_ = table[len(left)][len(right)]
+	}
+}
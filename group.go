@@ -0,0 +1,148 @@
+package golcs
+
+import "reflect"
+
+// GroupKind represents the kind of a GroupDiff.
+type GroupKind int
+
+const (
+	// GroupMatched is a group in both Left and Right.
+	GroupMatched GroupKind = iota
+	// GroupRemoved is a group only in Left.
+	GroupRemoved
+	// GroupAdded is a group only in Right.
+	GroupAdded
+)
+
+// GroupDiff is the difference of a group of records with the same key.
+type GroupDiff struct {
+	Kind GroupKind
+	Key  interface{}
+	// Common are the records in both groups, in the order of Left.
+	Common []interface{}
+	// Removed are the records only in the group of Left, in their order.
+	Removed []interface{}
+	// Added are the records only in the group of Right, in their order.
+	Added []interface{}
+}
+
+// DiffGroups compares two arrays of records in two levels: the order of groups
+// matters while the order of records in a group does not.
+//
+// The records of each array are grouped by key, and the groups are ordered by
+// the first record of each. The keys of the groups are compared as an LCS
+// with reflect.DeepEqual, and the result has a GroupDiff for each group in the
+// order of EditScript() over the keys: a GroupMatched for a key in the LCS, and
+// a GroupRemoved or a GroupAdded with all the records of the group otherwise.
+// A key which is in both arrays but out of order in one of them is a removed
+// group and an added group. In a matched group, each record of Left is paired
+// with the first unpaired record of Right which is the same, compared with
+// the options as in New(), and the unpaired records are Removed or Added.
+func DiffGroups(left, right []interface{}, key func(interface{}) interface{}, opts ...Option) []GroupDiff {
+	leftKeys, leftGroups := groupBy(left, key)
+	rightKeys, rightGroups := groupBy(right, key)
+
+	diffs := []GroupDiff{}
+	for _, edit := range New(leftKeys, rightKeys).EditScript() {
+		switch edit.Kind {
+		case EditEqual:
+			diff := diffRecords(leftGroups[edit.Left], rightGroups[edit.Right], opts)
+			diff.Key = edit.Value
+			diffs = append(diffs, diff)
+		case EditDelete:
+			diffs = append(diffs, GroupDiff{Kind: GroupRemoved, Key: edit.Value, Common: []interface{}{}, Removed: leftGroups[edit.Left], Added: []interface{}{}})
+		case EditInsert:
+			diffs = append(diffs, GroupDiff{Kind: GroupAdded, Key: edit.Value, Common: []interface{}{}, Removed: []interface{}{}, Added: rightGroups[edit.Right]})
+		}
+	}
+	return diffs
+}
+
+// groupBy groups records by key in the order of their first records. The
+// keys for which == agrees with reflect.DeepEqual are found in a map, and
+// the others, such as pointers, slices and maps, by a linear scan of those
+// seen before.
+func groupBy(records []interface{}, key func(interface{}) interface{}) (keys []interface{}, groups [][]interface{}) {
+	keys = []interface{}{}
+	groups = [][]interface{}{}
+	indices := map[interface{}]int{}
+	others := []int{}
+	for _, record := range records {
+		k := key(record)
+		plain := k == nil || isPlainType(reflect.TypeOf(k))
+		i, found := -1, false
+		if plain {
+			i, found = indices[k]
+		} else {
+			for _, j := range others {
+				if reflect.DeepEqual(keys[j], k) {
+					i, found = j, true
+					break
+				}
+			}
+		}
+		if found {
+			groups[i] = append(groups[i], record)
+			continue
+		}
+
+		if plain {
+			indices[k] = len(keys)
+		} else {
+			others = append(others, len(keys))
+		}
+		keys = append(keys, k)
+		groups = append(groups, []interface{}{record})
+	}
+	return keys, groups
+}
+
+// isPlainType reports whether the values of t are compared by == as by
+// reflect.DeepEqual, which is the case of the basic types and the arrays and
+// structs of them, but not of the pointers and interfaces they may contain.
+func isPlainType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isPlainType(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isPlainType(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// diffRecords pairs the records of two groups regardless of their order.
+func diffRecords(left, right []interface{}, opts []Option) GroupDiff {
+	records := New(left, right, opts...).(*lcs)
+	diff := GroupDiff{Kind: GroupMatched, Common: []interface{}{}, Removed: []interface{}{}, Added: []interface{}{}}
+	paired := make([]bool, len(right))
+	for x := range left {
+		y := 0
+		for ; y < len(right); y++ {
+			if !paired[y] && records.match(x, y) {
+				break
+			}
+		}
+		if y < len(right) {
+			paired[y] = true
+			diff.Common = append(diff.Common, left[x])
+		} else {
+			diff.Removed = append(diff.Removed, left[x])
+		}
+	}
+	for y := range right {
+		if !paired[y] {
+			diff.Added = append(diff.Added, right[y])
+		}
+	}
+	return diff
+}
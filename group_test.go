@@ -0,0 +1,86 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffGroups(t *testing.T) {
+	type record struct {
+		team string
+		name string
+	}
+	team := func(r interface{}) interface{} { return r.(record).team }
+
+	left := []interface{}{
+		record{"a", "alice"}, record{"a", "bob"},
+		record{"b", "carol"},
+		record{"c", "dave"}, record{"c", "erin"},
+		record{"a", "frank"},
+	}
+	right := []interface{}{
+		record{"a", "frank"}, record{"a", "alice"}, record{"a", "bob"},
+		record{"c", "erin"}, record{"c", "grace"},
+		record{"d", "heidi"},
+	}
+
+	expected := []GroupDiff{
+		{
+			Kind:    GroupMatched,
+			Key:     "a",
+			Common:  []interface{}{record{"a", "alice"}, record{"a", "bob"}, record{"a", "frank"}},
+			Removed: []interface{}{},
+			Added:   []interface{}{},
+		},
+		{
+			Kind:    GroupRemoved,
+			Key:     "b",
+			Common:  []interface{}{},
+			Removed: []interface{}{record{"b", "carol"}},
+			Added:   []interface{}{},
+		},
+		{
+			Kind:    GroupMatched,
+			Key:     "c",
+			Common:  []interface{}{record{"c", "erin"}},
+			Removed: []interface{}{record{"c", "dave"}},
+			Added:   []interface{}{record{"c", "grace"}},
+		},
+		{
+			Kind:    GroupAdded,
+			Key:     "d",
+			Common:  []interface{}{},
+			Removed: []interface{}{},
+			Added:   []interface{}{record{"d", "heidi"}},
+		},
+	}
+
+	actual := DiffGroups(left, right, team)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %#v, expected: %#v", actual, expected)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	type pair struct {
+		a int
+		b string
+	}
+	one, otherOne := 1, 1
+	records := []interface{}{
+		pair{1, "x"}, []int{1}, &one, nil, "s",
+		pair{1, "x"}, []int{1}, &otherOne, nil, "s", pair{2, "x"},
+	}
+
+	// the keys are the records, with the pointers compared by their values
+	keys, groups := groupBy(records, func(r interface{}) interface{} { return r })
+	expected := []interface{}{pair{1, "x"}, []int{1}, &one, nil, "s", pair{2, "x"}}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("failed at keys, actual: %v, expected: %v", keys, expected)
+	}
+	for i, group := range groups {
+		if size := 2 - i/5; len(group) != size {
+			t.Errorf("failed at group %d, actual: %v, expected size: %v", i, group, size)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package golcs
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// maxHeatmapSize is the maximum width and height of images by WriteHeatmap().
+const maxHeatmapSize = 1024
+
+// WriteHeatmap implements LCS.WriteHeatmap()
+//
+// The image is a grayscale PNG of the memo table where the x axis is Left and
+// the y axis is Right, including the first row and column of zeros. The
+// brightness of a pixel is proportional to the length at the cell, from black
+// for 0 to white for Length(). The width and the height are at most 1024
+// pixels; a larger table is downsampled by picking the nearest cell for each
+// pixel, keeping the first and last cells on the edges.
+func (lcs *lcs) WriteHeatmap(w io.Writer) error {
+	table, err := lcs.TableContext(context.Background())
+	if err != nil {
+		return err
+	}
+
+	sizeX, sizeY := len(table), len(table[0])
+	width, height := min(sizeX, maxHeatmapSize), min(sizeY, maxHeatmapSize)
+	length := table[sizeX-1][sizeY-1]
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for py := 0; py < height; py++ {
+		y := heatmapCell(py, height, sizeY)
+		for px := 0; px < width; px++ {
+			x := heatmapCell(px, width, sizeX)
+			brightness := uint8(0)
+			if length > 0 {
+				brightness = uint8(table[x][y] * 255 / length)
+			}
+			img.SetGray(px, py, color.Gray{Y: brightness})
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// heatmapCell returns the index of the cell among size cells for a pixel among pixels.
+func heatmapCell(pixel, pixels, size int) int {
+	if pixels == 1 {
+		return 0
+	}
+	return pixel * (size - 1) / (pixels - 1)
+}
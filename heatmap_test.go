@@ -0,0 +1,54 @@
+package golcs
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestWriteHeatmap(t *testing.T) {
+	cases := []struct {
+		left   []interface{}
+		right  []interface{}
+		width  int
+		height int
+	}{
+		{
+			left:   []interface{}{1, 2, 3},
+			right:  []interface{}{2, 3},
+			width:  4,
+			height: 3,
+		},
+		{
+			left:   make([]interface{}, 2000),
+			right:  make([]interface{}, 10),
+			width:  1024,
+			height: 11,
+		},
+	}
+
+	for i, c := range cases {
+		buffer := &bytes.Buffer{}
+		if err := New(c.left, c.right).WriteHeatmap(buffer); err != nil {
+			t.Fatalf("test case %d failed, unexpected err: %s", i, err)
+		}
+
+		img, err := png.Decode(buffer)
+		if err != nil {
+			t.Fatalf("test case %d failed to decode, unexpected err: %s", i, err)
+		}
+		bounds := img.Bounds()
+		if bounds.Dx() != c.width || bounds.Dy() != c.height {
+			t.Errorf("test case %d failed at size, actual: %dx%d, expected: %dx%d", i, bounds.Dx(), bounds.Dy(), c.width, c.height)
+		}
+
+		gray := img.(*image.Gray)
+		if origin := gray.GrayAt(0, 0).Y; origin != 0 {
+			t.Errorf("test case %d failed at origin, actual: %d", i, origin)
+		}
+		if corner := gray.GrayAt(bounds.Dx()-1, bounds.Dy()-1).Y; corner != 255 {
+			t.Errorf("test case %d failed at corner, actual: %d", i, corner)
+		}
+	}
+}
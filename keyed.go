@@ -0,0 +1,79 @@
+package golcs
+
+import (
+	"context"
+	"reflect"
+)
+
+// WithKey compares elements by the keys given by key instead of the elements
+// themselves, for example the IDs of records. Values() and the other results
+// still have the original elements. The key is applied like a normalizer of
+// WithNormalizers(), after those of the options given before.
+func WithKey(key func(interface{}) interface{}) Option {
+	return WithNormalizers(key)
+}
+
+// KeyedEditKind represents the kind of a KeyedEdit.
+type KeyedEditKind int
+
+const (
+	// KeyedUnchanged is an element in both Left and Right with the same content.
+	KeyedUnchanged KeyedEditKind = iota
+	// KeyedModified is an element in both Left and Right with different contents.
+	KeyedModified
+	// KeyedRemoved is an element only in Left.
+	KeyedRemoved
+	// KeyedAdded is an element only in Right.
+	KeyedAdded
+)
+
+// KeyedEdit is a change of an element identified by its key.
+type KeyedEdit struct {
+	Kind KeyedEditKind
+	// Left is the index in Left, or -1 for KeyedAdded.
+	Left int
+	// Right is the index in Right, or -1 for KeyedRemoved.
+	Right int
+	// LeftValue is the element of Left, or nil for KeyedAdded.
+	LeftValue interface{}
+	// RightValue is the element of Right, or nil for KeyedRemoved.
+	RightValue interface{}
+}
+
+// KeyedDiff implements LCS.KeyedDiff()
+func (lcs *lcs) KeyedDiff() []KeyedEdit {
+	edits, _ := lcs.KeyedDiffContext(context.Background())
+	return edits
+}
+
+// KeyedDiffContext implements LCS.KeyedDiffContext()
+//
+// It is meant to be used with WithKey(): elements are matched by their keys
+// as in EditScript(), and then the matched elements are compared by their
+// whole contents with reflect.DeepEqual. A matched pair is KeyedUnchanged
+// when the contents are the same and KeyedModified otherwise, and the other
+// elements are KeyedRemoved or KeyedAdded. The edits are in the order of
+// EditScript().
+func (lcs *lcs) KeyedDiffContext(ctx context.Context) ([]KeyedEdit, error) {
+	script, err := lcs.EditScriptContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	edits := make([]KeyedEdit, len(script))
+	for i, edit := range script {
+		switch edit.Kind {
+		case EditEqual:
+			kind := KeyedUnchanged
+			if !reflect.DeepEqual(lcs.left[edit.Left], lcs.right[edit.Right]) {
+				kind = KeyedModified
+			}
+			edits[i] = KeyedEdit{Kind: kind, Left: edit.Left, Right: edit.Right, LeftValue: lcs.left[edit.Left], RightValue: lcs.right[edit.Right]}
+		case EditDelete:
+			edits[i] = KeyedEdit{Kind: KeyedRemoved, Left: edit.Left, Right: -1, LeftValue: edit.Value}
+		case EditInsert:
+			edits[i] = KeyedEdit{Kind: KeyedAdded, Left: -1, Right: edit.Right, RightValue: edit.Value}
+		}
+	}
+	return edits, nil
+}
@@ -0,0 +1,33 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyedDiff(t *testing.T) {
+	type item struct {
+		id   int
+		text string
+	}
+	id := func(value interface{}) interface{} { return value.(item).id }
+
+	left := []interface{}{item{1, "a"}, item{2, "b"}, item{3, "c"}}
+	right := []interface{}{item{1, "a"}, item{3, "C"}, item{4, "d"}}
+	newLcs := New(left, right, WithKey(id))
+
+	expected := []KeyedEdit{
+		{Kind: KeyedUnchanged, Left: 0, Right: 0, LeftValue: item{1, "a"}, RightValue: item{1, "a"}},
+		{Kind: KeyedRemoved, Left: 1, Right: -1, LeftValue: item{2, "b"}},
+		{Kind: KeyedModified, Left: 2, Right: 1, LeftValue: item{3, "c"}, RightValue: item{3, "C"}},
+		{Kind: KeyedAdded, Left: -1, Right: 2, RightValue: item{4, "d"}},
+	}
+	if actual := newLcs.KeyedDiff(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %#v, expected: %#v", actual, expected)
+	}
+
+	expectedValues := []interface{}{item{1, "a"}, item{3, "c"}}
+	if actual := newLcs.Values(); !reflect.DeepEqual(actual, expectedValues) {
+		t.Errorf("failed at values, actual: %#v, expected: %#v", actual, expectedValues)
+	}
+}
@@ -0,0 +1,69 @@
+package golcs
+
+import "reflect"
+
+// WithLengthRatio treats two elements with lengths, such as strings, as not
+// the same without comparing them when the longer is more than ratio times as
+// long as the shorter, which saves most of the comparisons of long elements
+// which rarely match.
+//
+// The length of each element of Left and Right is taken once when the
+// calculator is created, after the transforms of the options: the bytes of
+// a string and the elements of a slice, an array or a map. Elements without
+// a length, and every pair when one of them has none, are compared as
+// without the option. With the default equality, elements of different
+// lengths are never the same, so any ratio of 1 or more gives the same
+// results, only faster. It is a heuristic for the equalities given with
+// WithEqual() and the other options, which may well find elements of
+// different lengths the same, like a case-insensitive comparison of strings
+// where a letter changes its size, so the results may differ and the option
+// is opt-in. A ratio < 1 disables the option.
+func WithLengthRatio(ratio float64) Option {
+	return func(lcs *lcs) {
+		lcs.lengthRatio = ratio
+	}
+}
+
+// measure takes the lengths of WithLengthRatio(), or -1 for an element
+// without a length.
+func (lcs *lcs) measure() {
+	lcs.leftLengths, lcs.rightLengths = nil, nil
+	if lcs.lengthRatio < 1 {
+		return
+	}
+	lcs.leftLengths = elementLengths(lcs.leftKeys)
+	lcs.rightLengths = elementLengths(lcs.rightKeys)
+}
+
+func elementLengths(keys []interface{}) []int {
+	lengths := make([]int, len(keys))
+	for i, key := range keys {
+		switch v := key.(type) {
+		case string:
+			lengths[i] = len(v)
+		case []byte:
+			lengths[i] = len(v)
+		default:
+			switch value := reflect.ValueOf(key); value.Kind() {
+			case reflect.Array, reflect.Slice, reflect.Map:
+				lengths[i] = value.Len()
+			default:
+				lengths[i] = -1
+			}
+		}
+	}
+	return lengths
+}
+
+// lengthsDiffer reports whether Left[x] and Right[y] are too different in
+// length to be the same by WithLengthRatio().
+func (lcs *lcs) lengthsDiffer(x, y int) bool {
+	a, b := lcs.leftLengths[x], lcs.rightLengths[y]
+	if a < 0 || b < 0 {
+		return false
+	}
+	if a > b {
+		a, b = b, a
+	}
+	return float64(b) > float64(a)*lcs.lengthRatio
+}
@@ -0,0 +1,86 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithLengthRatio(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomStrings(random, random.Intn(30), 4)
+		right := randomStrings(random, random.Intn(30), 4)
+		if i%2 == 0 {
+			// elements without lengths fall through
+			left = append(left, 1, nil, []int{1})
+			right = append(right, 1, nil, []int{1})
+		}
+		expected := New(left, right).IndexPairs()
+
+		for _, ratio := range []float64{1, 1.5, 4} {
+			if actual := New(left, right, WithLengthRatio(ratio)).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+				t.Errorf("test case %d failed at ratio %v, actual: %v, expected: %v", i, ratio, actual, expected)
+			}
+		}
+	}
+}
+
+func TestWithLengthRatioCustomEqual(t *testing.T) {
+	prefix := WithEqual(func(a, b interface{}) bool {
+		return strings.HasPrefix(b.(string), a.(string))
+	})
+	left := []interface{}{"a", "ab", "abc"}
+	right := []interface{}{"abcd", "abcd", "abcd"}
+
+	cases := []struct {
+		ratio  float64
+		length int
+	}{
+		{ratio: 0, length: 3},
+		{ratio: 1, length: 0},
+		{ratio: 1.5, length: 1},
+		{ratio: 2, length: 2},
+		{ratio: 4, length: 3},
+	}
+
+	for i, c := range cases {
+		if actual := New(left, right, prefix, WithLengthRatio(c.ratio)).Length(); actual != c.length {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.length)
+		}
+	}
+}
+
+// randomStrings generates strings of 1 to 3 letters among kinds.
+func randomStrings(random *rand.Rand, size, kinds int) []interface{} {
+	values := make([]interface{}, size)
+	for i := range values {
+		values[i] = strings.Repeat(string(rune('a'+random.Intn(kinds))), 1+random.Intn(3))
+	}
+	return values
+}
+
+func benchmarkLongStrings(b *testing.B, opts ...Option) {
+	random := rand.New(rand.NewSource(1))
+	// long strings of many lengths, which rarely match
+	left := make([]interface{}, 300)
+	right := make([]interface{}, 300)
+	for i := range left {
+		left[i] = strings.Repeat("x", 100+random.Intn(1000))
+		right[i] = strings.Repeat("x", 100+random.Intn(1000))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(left, right, opts...).Length()
+	}
+}
+
+func BenchmarkLongStrings(b *testing.B) {
+	benchmarkLongStrings(b)
+}
+
+func BenchmarkLongStringsWithLengthRatio(b *testing.B) {
+	benchmarkLongStrings(b, WithLengthRatio(1))
+}
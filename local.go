@@ -0,0 +1,137 @@
+package golcs
+
+import "context"
+
+// LocalDiff implements LCS.LocalDiff()
+func (lcs *lcs) LocalDiff(leftIndex, radius int) []Edit {
+	edits, _ := lcs.LocalDiffContext(context.Background(), leftIndex, radius)
+	return edits
+}
+
+// LocalDiffContext implements LCS.LocalDiffContext()
+//
+// The window is Left[leftIndex-radius:leftIndex+radius+1], cut at the ends of
+// Left. The Right region is found from two anchors, the nearest elements
+// before and after the window which appear exactly once in both Left and
+// Right and match, like the unique elements aligned by WithPatience(): the
+// Right region is between the Right elements of the anchors, and the ends of
+// the arrays serve as anchors when there is none. Only the regions between the
+// anchors are diffed, with the memo table whatever the engine, and the result
+// is the edits between the edit of Left[leftIndex-radius-1] and that of
+// Left[leftIndex+radius+1], so it has the edits of the window and the
+// insertions next to them. The indices are those of Left and Right. When the
+// full diff matches the anchors, which it does unless a longer common
+// subsequence crosses them, the local diff is the same slice of EditScript().
+// Only booleans, numbers and strings are anchors, and none of them are with
+// WithEqual() and the other options changing the equality, which diffs the
+// whole arrays.
+func (lcs *lcs) LocalDiffContext(ctx context.Context, leftIndex, radius int) ([]Edit, error) {
+	if err := lcs.compareAll(ctx); err != nil {
+		return nil, err
+	}
+	x0 := max(leftIndex-radius, 0)
+	x1 := min(leftIndex+radius+1, len(lcs.left))
+	if x0 >= x1 {
+		return []Edit{}, nil
+	}
+
+	before, after := lcs.localAnchors(x0, x1)
+	pairs := []IndexPair{}
+	if err := lcs.rangeIndexPairsContext(ctx, before.Left+1, after.Left, before.Right+1, after.Right, &pairs); err != nil {
+		return nil, err
+	}
+	if err := lcs.failed(); err != nil {
+		return nil, err
+	}
+
+	edits := []Edit{}
+	x, y := before.Left+1, before.Right+1
+	for i := 0; i <= len(pairs); i++ {
+		pair := after
+		if i < len(pairs) {
+			pair = pairs[i]
+		}
+		for ; x < pair.Left; x++ {
+			edits = append(edits, Edit{Kind: EditDelete, Left: x, Right: -1, Value: lcs.left[x]})
+		}
+		for ; y < pair.Right; y++ {
+			edits = append(edits, Edit{Kind: EditInsert, Left: -1, Right: y, Value: lcs.right[y]})
+		}
+		if i < len(pairs) {
+			edits = append(edits, Edit{Kind: EditEqual, Left: x, Right: y, Value: lcs.left[x]})
+			x++
+			y++
+		}
+	}
+
+	// cut the edits of Left before x0 and from x1
+	start, end := 0, len(edits)
+	for i, edit := range edits {
+		if edit.Left >= 0 && edit.Left < x0 {
+			start = i + 1
+		}
+		if edit.Left >= x1 {
+			end = i
+			break
+		}
+	}
+	return edits[start:end], nil
+}
+
+// localAnchors returns the nearest pairs of elements unique in both Left and
+// Right before Left[x0] and from Left[x1], or the pairs just outside the ends
+// of the arrays when there is none.
+func (lcs *lcs) localAnchors(x0, x1 int) (before, after IndexPair) {
+	before = IndexPair{Left: -1, Right: -1}
+	after = IndexPair{Left: len(lcs.left), Right: len(lcs.right)}
+	if lcs.customEqual {
+		return before, after
+	}
+
+	leftCounts := map[interface{}]int{}
+	for _, key := range lcs.leftKeys {
+		if isBasicValue(key) {
+			leftCounts[key]++
+		}
+	}
+	type occurrence struct {
+		count int
+		index int
+	}
+	rightCounts := map[interface{}]*occurrence{}
+	for y, key := range lcs.rightKeys {
+		if isBasicValue(key) {
+			if found, ok := rightCounts[key]; ok {
+				found.count++
+			} else {
+				rightCounts[key] = &occurrence{count: 1, index: y}
+			}
+		}
+	}
+	anchor := func(x int) (int, bool) {
+		key := lcs.leftKeys[x]
+		if !isBasicValue(key) || leftCounts[key] != 1 {
+			return 0, false
+		}
+		right, ok := rightCounts[key]
+		if !ok || right.count != 1 || !lcs.match(x, right.index) {
+			return 0, false
+		}
+		return right.index, true
+	}
+
+	for x := x0 - 1; x >= 0; x-- {
+		if y, ok := anchor(x); ok {
+			before = IndexPair{Left: x, Right: y}
+			break
+		}
+	}
+	// an anchor after the window must also be after the one before it in Right
+	for x := x1; x < len(lcs.left); x++ {
+		if y, ok := anchor(x); ok && y > before.Right {
+			after = IndexPair{Left: x, Right: y}
+			break
+		}
+	}
+	return before, after
+}
@@ -0,0 +1,85 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestLocalDiff(t *testing.T) {
+	left := SplitLines("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(1)\n\tfmt.Println(2)\n}\n\nfunc other() {\n\treturn\n}\n")
+	right := SplitLines("package main\n\nimport \"os\"\n\nfunc main() {\n\tfmt.Println(1)\n\tos.Exit(2)\n\tfmt.Println(3)\n}\n\nfunc other() {\n}\n")
+	full := New(left, right).EditScript()
+
+	cases := []struct {
+		leftIndex int
+		radius    int
+	}{
+		{leftIndex: 0, radius: 0},
+		{leftIndex: 2, radius: 0},
+		{leftIndex: 2, radius: 1},
+		{leftIndex: 6, radius: 0},
+		{leftIndex: 6, radius: 2},
+		{leftIndex: 10, radius: 0},
+		{leftIndex: 11, radius: 3},
+		{leftIndex: 5, radius: 100},
+		{leftIndex: -3, radius: 3},
+	}
+
+	for i, c := range cases {
+		actual := New(left, right).LocalDiff(c.leftIndex, c.radius)
+		expected := localSlice(full, c.leftIndex-c.radius, c.leftIndex+c.radius+1)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+
+	if actual := New(left, right).LocalDiff(len(left), 0); len(actual) != 0 {
+		t.Errorf("failed at the end, actual: %v", actual)
+	}
+	if actual := New(left, right).LocalDiff(2, -1); len(actual) != 0 {
+		t.Errorf("failed at negative radius, actual: %v", actual)
+	}
+}
+
+func TestLocalDiffRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(40), 30)
+		right := randomInts(random, random.Intn(40), 30)
+		leftIndex, radius := random.Intn(40), random.Intn(5)
+
+		edits := New(left, right).LocalDiff(leftIndex, radius)
+		x := max(leftIndex-radius, 0)
+		for j, edit := range edits {
+			if edit.Kind != EditInsert && edit.Left != x {
+				t.Fatalf("test case %d failed at edit %d, actual: %v, expected left: %v", i, j, edit, x)
+			}
+			if edit.Kind != EditInsert {
+				x++
+			}
+			if edit.Kind == EditEqual && !reflect.DeepEqual(left[edit.Left], right[edit.Right]) {
+				t.Fatalf("test case %d failed at edit %d, not a match: %v", i, j, edit)
+			}
+		}
+		if end := min(leftIndex+radius+1, len(left)); x < end {
+			t.Errorf("test case %d failed at coverage, actual: %v, expected: %v", i, x, end)
+		}
+	}
+}
+
+// localSlice returns the edits of a script after the edit of Left[x0-1] and
+// before that of Left[x1].
+func localSlice(edits []Edit, x0, x1 int) []Edit {
+	start, end := 0, len(edits)
+	for i, edit := range edits {
+		if edit.Left >= 0 && edit.Left < x0 {
+			start = i + 1
+		}
+		if edit.Left >= x1 {
+			end = i
+			break
+		}
+	}
+	return edits[start:end]
+}
@@ -0,0 +1,144 @@
+package golcs
+
+import "context"
+
+// WithManyToOne finds IndexPairs() with an alignment where an element may
+// match several contiguous elements of the other array, like a token matching
+// the subtokens it was split into, reported by Matches().
+//
+// The rule of a common subsequence is relaxed to groups: a group is an
+// element of Left matching a run of contiguous elements of Right, or an
+// element of Right matching a run of Left, and each element is in one group
+// at most. The groups are strictly increasing, so every element of a group
+// comes after those of the group before it in both arrays, and only the
+// elements within a group share an index. Each group scores 1 and each
+// element beyond the first of the run scores 1/(m+n+1) for a Left of size m
+// and a Right of size n, so all the extensions together score less than a
+// single group: the groups are always as many as the pairs of an LCS, and
+// the runs are only extended by the elements left over by such an alignment.
+// An element loosely matching everything, such as with WithEqual(), never
+// swallows elements which an LCS matches elsewhere, but it does take all the
+// unmatched elements next to it. The alignment of the highest score is found
+// in O(mn) time and memory. IndexPairs() are the first pairs of the groups,
+// so the rest of the runs are deleted and inserted by the edit scripts, and
+// Length() is the number of groups, the same as without the option.
+func WithManyToOne() Option {
+	return func(lcs *lcs) {
+		lcs.manyToOne = true
+	}
+}
+
+// Match is a group of contiguous elements of Left and Right matched together.
+// One of the ranges has exactly one element, unless both have. The ranges
+// are half-open, like those of Opcode.
+type Match struct {
+	LeftStart  int
+	LeftEnd    int
+	RightStart int
+	RightEnd   int
+}
+
+// Matches implements LCS.Matches()
+func (lcs *lcs) Matches() []Match {
+	matches, _ := lcs.MatchesContext(context.Background())
+	return matches
+}
+
+// MatchesContext implements LCS.MatchesContext()
+//
+// Without WithManyToOne(), each group is a single pair of IndexPairs().
+// WithMaxGap(), WithMonotoneAttribute() and WithScoredMatch() take precedence
+// over WithManyToOne(), and the groups are then the single pairs they choose.
+func (lcs *lcs) MatchesContext(ctx context.Context) ([]Match, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if lcs.matches != nil {
+		return append([]Match{}, lcs.matches[:len(pairs)]...), nil
+	}
+
+	matches := make([]Match, len(pairs))
+	for i, pair := range pairs {
+		matches[i] = Match{LeftStart: pair.Left, LeftEnd: pair.Left + 1, RightStart: pair.Right, RightEnd: pair.Right + 1}
+	}
+	return matches, nil
+}
+
+// manyToOneIndexPairsContext finds the groups of the highest score, with the
+// scores multiplied by m+n+1 to keep them integers. best[x][y] is the highest
+// score of Left[:x] and Right[:y], and byLeft[x][y] and byRight[x][y] are the
+// highest of those whose last group matches Left[x-1] and Right[y-1] and then
+// goes on in Right or Left, or -1 when they do not match.
+func (lcs *lcs) manyToOneIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	sizeX, sizeY := len(lcs.left)+1, len(lcs.right)+1
+	group := len(lcs.left) + len(lcs.right) + 1
+	best := make([][]int, sizeX)
+	byLeft := make([][]int, sizeX)
+	byRight := make([][]int, sizeX)
+	for x := 0; x < sizeX; x++ {
+		best[x] = make([]int, sizeY)
+		byLeft[x] = make([]int, sizeY)
+		byRight[x] = make([]int, sizeY)
+		for y := 0; y < sizeY; y++ {
+			byLeft[x][y], byRight[x][y] = -1, -1
+		}
+	}
+
+	for y := 1; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		for x := 1; x < sizeX; x++ {
+			if lcs.match(x-1, y-1) {
+				byLeft[x][y] = best[x-1][y-1] + group
+				if byLeft[x][y-1] >= 0 {
+					byLeft[x][y] = max(byLeft[x][y], byLeft[x][y-1]+1)
+				}
+				byRight[x][y] = best[x-1][y-1] + group
+				if byRight[x-1][y] >= 0 {
+					byRight[x][y] = max(byRight[x][y], byRight[x-1][y]+1)
+				}
+			}
+			best[x][y] = max(best[x-1][y], best[x][y-1], byLeft[x][y], byRight[x][y])
+		}
+	}
+
+	// backtrack the groups from the end
+	matches := []Match{}
+	for x, y := sizeX-1, sizeY-1; x > 0 && y > 0; {
+		switch best[x][y] {
+		case byLeft[x][y]:
+			start := y
+			for start > 1 && byLeft[x][start] != best[x-1][start-1]+group {
+				start--
+			}
+			matches = append(matches, Match{LeftStart: x - 1, LeftEnd: x, RightStart: start - 1, RightEnd: y})
+			x, y = x-1, start-1
+		case byRight[x][y]:
+			start := x
+			for start > 1 && byRight[start][y] != best[start-1][y-1]+group {
+				start--
+			}
+			matches = append(matches, Match{LeftStart: start - 1, LeftEnd: x, RightStart: y - 1, RightEnd: y})
+			x, y = start-1, y-1
+		case best[x-1][y]:
+			x--
+		default:
+			y--
+		}
+	}
+
+	pairs := make([]IndexPair, len(matches))
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	for i, match := range matches {
+		pairs[i] = IndexPair{Left: match.LeftStart, Right: match.RightStart}
+	}
+	lcs.matches = matches
+	return pairs, nil
+}
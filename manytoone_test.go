@@ -0,0 +1,132 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithManyToOne(t *testing.T) {
+	// a token matches each of its subtokens
+	subtoken := WithEqual(func(a, b interface{}) bool {
+		return strings.Contains(strings.ToLower(a.(string)), strings.ToLower(b.(string)))
+	})
+
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		opts     []Option
+		matches  []Match
+		standard []Match
+	}{
+		{
+			left:     []interface{}{"getUserName", "="},
+			right:    []interface{}{"get", "User", "Name", "="},
+			opts:     []Option{subtoken},
+			matches:  []Match{{0, 1, 0, 3}, {1, 2, 3, 4}},
+			standard: []Match{{0, 1, 2, 3}, {1, 2, 3, 4}},
+		},
+		{
+			left:     []interface{}{"a", "b"},
+			right:    []interface{}{"a", "a", "b"},
+			matches:  []Match{{0, 1, 0, 2}, {1, 2, 2, 3}},
+			standard: []Match{{0, 1, 1, 2}, {1, 2, 2, 3}},
+		},
+		{
+			left:     []interface{}{"a", "a", "b"},
+			right:    []interface{}{"a", "b"},
+			matches:  []Match{{0, 2, 0, 1}, {2, 3, 1, 2}},
+			standard: []Match{{1, 2, 0, 1}, {2, 3, 1, 2}},
+		},
+		{
+			// one to one is preferred to groups
+			left:     []interface{}{"a", "a"},
+			right:    []interface{}{"a", "a"},
+			matches:  []Match{{0, 1, 0, 1}, {1, 2, 1, 2}},
+			standard: []Match{{0, 1, 0, 1}, {1, 2, 1, 2}},
+		},
+		{
+			// the runs are contiguous
+			left:     []interface{}{"a"},
+			right:    []interface{}{"a", "x", "a"},
+			matches:  []Match{{0, 1, 2, 3}},
+			standard: []Match{{0, 1, 2, 3}},
+		},
+		{
+			// a run never takes the elements matched by an LCS
+			left:     []interface{}{"a", "b", "c"},
+			right:    []interface{}{"b", "c", "a", "a", "a", "a", "a", "a"},
+			matches:  []Match{{1, 2, 0, 1}, {2, 3, 1, 2}},
+			standard: []Match{{1, 2, 0, 1}, {2, 3, 1, 2}},
+		},
+		{
+			left:     []interface{}{},
+			right:    []interface{}{"a"},
+			matches:  []Match{},
+			standard: []Match{},
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right, append(c.opts, WithManyToOne())...)
+		if actual := newLcs.Matches(); !reflect.DeepEqual(actual, c.matches) {
+			t.Errorf("test case %d failed at matches, actual: %v, expected: %v", i, actual, c.matches)
+		}
+		if actual := newLcs.Length(); actual != len(c.matches) {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, len(c.matches))
+		}
+		if actual := New(c.left, c.right, c.opts...).Matches(); !reflect.DeepEqual(actual, c.standard) {
+			t.Errorf("test case %d failed at standard, actual: %v, expected: %v", i, actual, c.standard)
+		}
+	}
+}
+
+func TestWithManyToOnePrecedence(t *testing.T) {
+	left := []interface{}{"a", "b", "c"}
+	right := []interface{}{"a", "a", "x", "c"}
+	opts := []Option{WithMaxGap(1), WithMonotoneAttribute(func(interface{}) float64 { return 0 })}
+
+	for i, opt := range opts {
+		newLcs := New(left, right, WithManyToOne(), opt)
+		pairs := newLcs.IndexPairs()
+		expected := make([]Match, len(pairs))
+		for j, pair := range pairs {
+			expected[j] = Match{pair.Left, pair.Left + 1, pair.Right, pair.Right + 1}
+		}
+		if actual := newLcs.Matches(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+}
+
+func TestWithManyToOneRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+
+		newLcs := New(left, right, WithManyToOne())
+		matches := newLcs.Matches()
+		checkCommonSubsequence(t, i, left, right, newLcs.IndexPairs())
+		for j, match := range matches {
+			if match.LeftEnd-match.LeftStart != 1 && match.RightEnd-match.RightStart != 1 {
+				t.Fatalf("test case %d failed at match %d, not one to many: %v", i, j, match)
+			}
+			if j > 0 && (match.LeftStart < matches[j-1].LeftEnd || match.RightStart < matches[j-1].RightEnd) {
+				t.Fatalf("test case %d failed at match %d, actual: %v after %v", i, j, match, matches[j-1])
+			}
+			for x := match.LeftStart; x < match.LeftEnd; x++ {
+				for y := match.RightStart; y < match.RightEnd; y++ {
+					if left[x] != right[y] {
+						t.Fatalf("test case %d failed at match %d, not a match: %v", i, j, match)
+					}
+				}
+			}
+		}
+		// the groups are as many as the pairs of an LCS
+		if expected := New(left, right).Length(); len(matches) != expected || newLcs.Length() != expected {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, len(matches), expected)
+		}
+	}
+}
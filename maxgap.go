@@ -0,0 +1,136 @@
+package golcs
+
+import "context"
+
+// WithMaxGap limits the gaps between two consecutive matches of the LCS.
+//
+// For consecutive index pairs p and q of IndexPairs(), both q.Left-p.Left-1
+// and q.Right-p.Right-1, the numbers of elements skipped between them in
+// Left and Right, must be at most g. Elements before the first match and after
+// the last one are not limited. The result is the longest common subsequence
+// satisfying the constraint, which may be shorter than the unconstrained LCS:
+// a run of matches is cut where the next match would be too far away. g = 0
+// gives the longest common substring. Length() and the methods built on
+// IndexPairs() follow the constraint, while Table() and SuffixTable() are
+// still the memo tables of the unconstrained LCS. g < 0 disables the option.
+// The constrained LCS is found in O(mn) time and memory for a Left of size m
+// and a Right of size n, with O(m*min(g+1, n)) more memory for the windows,
+// and up to O((g+1)^2) time for each of its pairs to trace them back.
+func WithMaxGap(g int) Option {
+	return func(lcs *lcs) {
+		lcs.maxGap = g
+		lcs.constrainGap = g >= 0
+	}
+}
+
+// gapConstrained reports whether WithMaxGap() can change the LCS.
+// A gap never exceeds the size of the arrays, so a larger g changes nothing.
+func (lcs *lcs) gapConstrained() bool {
+	return lcs.constrainGap && lcs.maxGap < max(len(lcs.left), len(lcs.right))
+}
+
+// maxGapIndexPairsContext finds the pairs with a DP over the matches:
+// chain[x][y] is the length of the longest constrained chain of matches
+// ending at the match of x-1 and y-1, or 0 when they do not match.
+//
+// The candidates of a match are the chains ending in the window of g+1 cells
+// before it in both Left and Right. The maxima of each row over the window of
+// Left are found with a sliding window, and those of the rows over the window
+// of Right are kept for each column in a deque of decreasing lengths, so the
+// table takes O(mn) time and the deques O(m*min(g+1, n)) memory besides it.
+// Tracing the chain back looks in the window of each of its pairs, in
+// O((g+1)^2) time each at most.
+func (lcs *lcs) maxGapIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	sizeX := len(lcs.left) + 1
+	sizeY := len(lcs.right) + 1
+	window := lcs.maxGap + 1
+
+	chain := make([][]int, sizeX)
+	for x := 0; x < sizeX; x++ {
+		chain[x] = make([]int, sizeY)
+	}
+	// columns[x] has the longest chains in chain[x-window:x][y] of the rows y
+	// in the window, the candidates in y for a match in x, with decreasing
+	// lengths from the oldest row
+	columns := make([][]gapCandidate, sizeX)
+
+	best, bestX, bestY := 0, 0, 0
+	for y := 1; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		for x := 1; x < sizeX; x++ {
+			if !lcs.match(x-1, y-1) {
+				continue
+			}
+			longest := 0
+			if column := columns[x]; len(column) > 0 {
+				longest = column[0].length
+			}
+			chain[x][y] = longest + 1
+			if chain[x][y] > best {
+				best, bestX, bestY = chain[x][y], x, y
+			}
+		}
+
+		reach := slidingMax(chain, y, window)
+		for x := 1; x < sizeX; x++ {
+			column := columns[x]
+			for len(column) > 0 && column[len(column)-1].length <= reach[x] {
+				column = column[:len(column)-1]
+			}
+			column = append(column, gapCandidate{y: y, length: reach[x]})
+			// the next row is y+1, whose window starts at y+1-window
+			for column[0].y < y+1-window {
+				column = column[1:]
+			}
+			columns[x] = column
+		}
+	}
+
+	pairs := make([]IndexPair, best)
+	for x, y := bestX, bestY; best > 0; best-- {
+		pairs[best-1] = IndexPair{Left: x - 1, Right: y - 1}
+		x, y = previousInChain(chain, x, y, window)
+	}
+	return pairs, nil
+}
+
+// gapCandidate is the longest chain of a row in the window of a column.
+type gapCandidate struct {
+	y      int
+	length int
+}
+
+// slidingMax calculates the maximum of chain[x-window:x][y] for each x.
+func slidingMax(chain [][]int, y, window int) []int {
+	maxima := make([]int, len(chain))
+	// indices of x with decreasing chain lengths in the window
+	deque := []int{}
+	for x := 1; x < len(chain); x++ {
+		for len(deque) > 0 && chain[deque[len(deque)-1]][y] <= chain[x-1][y] {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, x-1)
+		if deque[0] < x-window {
+			deque = deque[1:]
+		}
+		maxima[x] = chain[deque[0]][y]
+	}
+	return maxima
+}
+
+// previousInChain finds the match before x and y in the longest chain ending there.
+func previousInChain(chain [][]int, x, y, window int) (int, int) {
+	for prevY := y - 1; prevY >= max(1, y-window); prevY-- {
+		for prevX := x - 1; prevX >= max(1, x-window); prevX-- {
+			if chain[prevX][prevY] == chain[x][y]-1 {
+				return prevX, prevY
+			}
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,125 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithMaxGap(t *testing.T) {
+	cases := []struct {
+		left   []interface{}
+		right  []interface{}
+		gap    int
+		pairs  []IndexPair
+		length int
+	}{
+		{
+			// the unconstrained LCS is 1, 2, 3, 4
+			left:   []interface{}{1, 2, 0, 0, 0, 3, 4},
+			right:  []interface{}{1, 2, 3, 4},
+			gap:    1,
+			pairs:  []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}},
+			length: 2,
+		},
+		{
+			left:   []interface{}{1, 2, 0, 0, 0, 3, 4},
+			right:  []interface{}{1, 2, 3, 4},
+			gap:    3,
+			pairs:  []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}, {Left: 5, Right: 2}, {Left: 6, Right: 3}},
+			length: 4,
+		},
+		{
+			// a longer run after the gap wins
+			left:   []interface{}{1, 9, 9, 9, 2, 3, 4},
+			right:  []interface{}{1, 2, 3, 4},
+			gap:    2,
+			pairs:  []IndexPair{{Left: 4, Right: 1}, {Left: 5, Right: 2}, {Left: 6, Right: 3}},
+			length: 3,
+		},
+		{
+			// the longest common substring
+			left:   []interface{}{"a", "b", "x", "c", "d", "e"},
+			right:  []interface{}{"a", "b", "c", "d", "e"},
+			gap:    0,
+			pairs:  []IndexPair{{Left: 3, Right: 2}, {Left: 4, Right: 3}, {Left: 5, Right: 4}},
+			length: 3,
+		},
+		{
+			// the gap in Right is limited as well
+			left:   []interface{}{1, 2},
+			right:  []interface{}{1, 0, 0, 2},
+			gap:    1,
+			pairs:  []IndexPair{{Left: 0, Right: 0}},
+			length: 1,
+		},
+		{
+			left:   []interface{}{1, 2, 3},
+			right:  []interface{}{4, 5},
+			gap:    1,
+			pairs:  []IndexPair{},
+			length: 0,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right, WithMaxGap(c.gap))
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+		if actual := New(c.left, c.right, WithMaxGap(c.gap)).Length(); actual != c.length {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, c.length)
+		}
+	}
+}
+
+func TestWithMaxGapRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(20), 3)
+		right := randomInts(random, random.Intn(20), 3)
+		gap := random.Intn(4)
+
+		pairs := New(left, right, WithMaxGap(gap)).IndexPairs()
+		for j, pair := range pairs {
+			if !reflect.DeepEqual(left[pair.Left], right[pair.Right]) {
+				t.Fatalf("test case %d failed at pair %d, not a match: %v", i, j, pair)
+			}
+			if j > 0 && (pair.Left-pairs[j-1].Left-1 > gap || pair.Right-pairs[j-1].Right-1 > gap ||
+				pair.Left <= pairs[j-1].Left || pair.Right <= pairs[j-1].Right) {
+				t.Fatalf("test case %d failed at pair %d, actual: %v after %v, gap: %d", i, j, pair, pairs[j-1], gap)
+			}
+		}
+		if expected := naiveMaxGapLength(left, right, gap); len(pairs) != expected {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, len(pairs), expected)
+		}
+	}
+
+	// a gap as large as the arrays is the same as no constraint
+	left := randomInts(random, 30, 3)
+	right := randomInts(random, 30, 3)
+	if actual, expected := New(left, right, WithMaxGap(30)).IndexPairs(), New(left, right).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at large gap, actual: %v, expected: %v", actual, expected)
+	}
+}
+
+// naiveMaxGapLength compares every pair of matches for TestWithMaxGapRandom.
+func naiveMaxGapLength(left, right []interface{}, gap int) int {
+	chain := map[IndexPair]int{}
+	best := 0
+	for x := range left {
+		for y := range right {
+			if !reflect.DeepEqual(left[x], right[y]) {
+				continue
+			}
+			chain[IndexPair{x, y}] = 1
+			for prev, length := range chain {
+				if prev.Left < x && prev.Right < y && x-prev.Left-1 <= gap && y-prev.Right-1 <= gap {
+					chain[IndexPair{x, y}] = max(chain[IndexPair{x, y}], length+1)
+				}
+			}
+			best = max(best, chain[IndexPair{x, y}])
+		}
+	}
+	return best
+}
@@ -0,0 +1,36 @@
+package golcs
+
+// WithMaxPairs stops collecting IndexPairs() after the first n pairs, for
+// callers who only need the start of an alignment of huge similar arrays.
+//
+// The dropped pairs are those at the tail, the matches of the largest
+// indices, and Truncated() reports whether any was dropped. Length() still
+// reflects the true length of the common subsequence, but Values(), the edit
+// scripts and the other methods on IndexPairs() see only the first n pairs,
+// so an EditScript() is still valid and deletes and inserts the elements of
+// the dropped matches. The memo table holds only n pairs while backtracking;
+// the other engines find all the pairs and keep the first n. n < 0 disables
+// the option.
+func WithMaxPairs(n int) Option {
+	return func(lcs *lcs) {
+		lcs.maxPairs = n
+		lcs.limitPairs = n >= 0
+	}
+}
+
+// Truncated implements LCS.Truncated()
+func (lcs *lcs) Truncated() bool {
+	lcs.IndexPairs()
+	return lcs.pairsTruncated
+}
+
+// truncatePairs keeps the first pairs within WithMaxPairs() and records the
+// true length of the pairs found.
+func (lcs *lcs) truncatePairs(pairs []IndexPair, length int) []IndexPair {
+	lcs.pairsLength = length
+	if lcs.limitPairs && len(pairs) > lcs.maxPairs {
+		pairs = append([]IndexPair{}, pairs[:lcs.maxPairs]...)
+	}
+	lcs.pairsTruncated = len(pairs) < length
+	return pairs
+}
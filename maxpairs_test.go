@@ -0,0 +1,86 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithMaxPairs(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	// large similar inputs: Right is Left with a few elements changed
+	left := randomInts(random, 2000, 50)
+	right := append([]interface{}{}, left...)
+	for i := 0; i < 20; i++ {
+		right[random.Intn(len(right))] = -1
+	}
+	full := New(left, right)
+	expected := full.IndexPairs()
+
+	cases := []struct {
+		n         int
+		opts      []Option
+		length    int
+		truncated bool
+	}{
+		{n: 100, length: 100, truncated: true},
+		{n: 0, length: 0, truncated: true},
+		{n: len(expected), length: len(expected), truncated: false},
+		{n: len(expected) + 1, length: len(expected), truncated: false},
+		{n: -1, length: len(expected), truncated: false},
+		{n: 100, opts: []Option{WithRowCheckpoints(16)}, length: 100, truncated: true},
+		{n: 100, opts: []Option{WithHuntSzymanski()}, length: 100, truncated: true},
+	}
+
+	for i, c := range cases {
+		newLcs := New(left, right, append(c.opts, WithMaxPairs(c.n))...)
+		pairs := newLcs.IndexPairs()
+		if len(pairs) != c.length {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, len(pairs), c.length)
+		}
+		if c.opts == nil && !reflect.DeepEqual(pairs, expected[:len(pairs)]) {
+			t.Errorf("test case %d failed at head, actual: %v, expected: %v", i, pairs, expected[:len(pairs)])
+		}
+		checkCommonSubsequence(t, i, left, right, pairs)
+		if actual := newLcs.Truncated(); actual != c.truncated {
+			t.Errorf("test case %d failed at truncated, actual: %v, expected: %v", i, actual, c.truncated)
+		}
+		if actual := newLcs.Length(); actual != len(expected) {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, len(expected))
+		}
+
+		// the edit script is still valid
+		applied, err := Apply(left, newLcs.EditScript())
+		if err != nil || !reflect.DeepEqual(applied, right) {
+			t.Errorf("test case %d failed at apply, error: %v", i, err)
+		}
+	}
+}
+
+func TestWithMaxPairsEngines(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+		n := random.Intn(10)
+
+		for j, opt := range []Option{WithPatience(), WithMaxGap(3), WithRarityWeighting()} {
+			expected := New(left, right, opt)
+			newLcs := New(left, right, opt, WithMaxPairs(n))
+			pairs := newLcs.IndexPairs()
+			all := expected.IndexPairs()
+			if len(all) > n {
+				all = all[:n]
+			}
+			if !reflect.DeepEqual(pairs, all) {
+				t.Errorf("test case %d failed at engine %d, actual: %v, expected: %v", i, j, pairs, all)
+			}
+			if actual := newLcs.Length(); actual != expected.Length() {
+				t.Errorf("test case %d failed at length of engine %d, actual: %v, expected: %v", i, j, actual, expected.Length())
+			}
+			if actual := newLcs.Truncated(); actual != (expected.Length() > n) {
+				t.Errorf("test case %d failed at truncated of engine %d, actual: %v", i, j, actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package golcs
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// MinHashSimilarity implements LCS.MinHashSimilarity()
+//
+// The similarity is an estimate of the Jaccard index of the sets of k-grams,
+// runs of k consecutive elements, of Left and Right. Each k-gram is hashed
+// from its elements formatted with %#v after the transforms of options such
+// as WithNormalizeNewlines(), and numHashes hash functions make the MinHash
+// signatures. The estimate gets closer to the actual index as numHashes grows,
+// with the standard error about 1/sqrt(numHashes).
+//
+// It is not related to the LCS at all: the order of the k-grams is ignored,
+// and WithEqual() and similar options are not used. It takes O((m+n)*numHashes)
+// time, so it is useful to cluster large inputs roughly before calculating the
+// LCS. The similarity is 1.0 when neither array has a k-gram, and 0.0 when only
+// one of them has none. numHashes and k less than 1 are treated as 1.
+func (lcs *lcs) MinHashSimilarity(numHashes, k int) float64 {
+	numHashes, k = max(numHashes, 1), max(k, 1)
+	leftSignature := minHashSignature(lcs.leftKeys, numHashes, k)
+	rightSignature := minHashSignature(lcs.rightKeys, numHashes, k)
+	if leftSignature == nil || rightSignature == nil {
+		if leftSignature == nil && rightSignature == nil {
+			return 1
+		}
+		return 0
+	}
+
+	same := 0
+	for i := range leftSignature {
+		if leftSignature[i] == rightSignature[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(numHashes)
+}
+
+// minHashSignature returns the MinHash signature of the k-grams of values, or
+// nil when there is no k-gram.
+func minHashSignature(values []interface{}, numHashes, k int) []uint64 {
+	grams := kGramHashes(values, k)
+	if len(grams) == 0 {
+		return nil
+	}
+
+	signature := make([]uint64, numHashes)
+	for i := range signature {
+		seed := uint64(i+1) * 0x9e3779b97f4a7c15
+		signature[i] = ^uint64(0)
+		for gram := range grams {
+			if hash := mix64(gram ^ seed); hash < signature[i] {
+				signature[i] = hash
+			}
+		}
+	}
+	return signature
+}
+
+// kGramHashes returns the set of hashes of the k-grams in values.
+func kGramHashes(values []interface{}, k int) map[uint64]struct{} {
+	hashes := make([]uint64, len(values))
+	for i, value := range values {
+		hashes[i] = hashValue(value)
+	}
+
+	grams := map[uint64]struct{}{}
+	for i := 0; i+k <= len(hashes); i++ {
+		gram := uint64(0)
+		for _, hash := range hashes[i : i+k] {
+			gram = mix64(gram ^ hash)
+		}
+		grams[gram] = struct{}{}
+	}
+	return grams
+}
+
+// hashValue hashes a value formatted with %#v.
+func hashValue(value interface{}) uint64 {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%#v", value)
+	return hash.Sum64()
+}
+
+// mix64 is the finalizer of SplitMix64, which makes a good hash of an uint64.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,63 @@
+package golcs
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMinHashSimilarity(t *testing.T) {
+	jaccard := func(left, right []interface{}, k int) float64 {
+		leftGrams, rightGrams := kGramHashes(left, k), kGramHashes(right, k)
+		intersection := 0
+		for gram := range leftGrams {
+			if _, ok := rightGrams[gram]; ok {
+				intersection++
+			}
+		}
+		return float64(intersection) / float64(len(leftGrams)+len(rightGrams)-intersection)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		left := randomInts(random, 300, 20)
+		// keep a random part of left to control the similarity
+		right := make([]interface{}, len(left))
+		for j := range left {
+			if random.Intn(10) < i {
+				right[j] = random.Intn(20)
+			} else {
+				right[j] = left[j]
+			}
+		}
+
+		for _, k := range []int{1, 2, 3} {
+			actual := New(left, right).MinHashSimilarity(400, k)
+			expected := jaccard(left, right, k)
+			if math.Abs(actual-expected) > 0.1 {
+				t.Errorf("test case %d failed with k = %d, actual: %f, expected: %f", i, k, actual, expected)
+			}
+		}
+	}
+}
+
+func TestMinHashSimilarityEdgeCases(t *testing.T) {
+	cases := []struct {
+		left       []interface{}
+		right      []interface{}
+		k          int
+		similarity float64
+	}{
+		{left: []interface{}{}, right: []interface{}{}, k: 1, similarity: 1},
+		{left: []interface{}{1}, right: []interface{}{1, 2}, k: 2, similarity: 0},
+		{left: []interface{}{1, 2, 3}, right: []interface{}{3, 1, 2}, k: 1, similarity: 1},
+		{left: []interface{}{1, 2}, right: []interface{}{3, 4}, k: 0, similarity: 0},
+	}
+
+	for i, c := range cases {
+		actual := New(c.left, c.right).MinHashSimilarity(16, c.k)
+		if actual != c.similarity {
+			t.Errorf("test case %d failed, actual: %f, expected: %f", i, actual, c.similarity)
+		}
+	}
+}
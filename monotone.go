@@ -0,0 +1,87 @@
+package golcs
+
+import "context"
+
+// WithMonotoneAttribute constrains the LCS to the matches in the order of an
+// attribute of the elements, such as the timestamps of events, so that an
+// alignment never goes back in time.
+//
+// For consecutive index pairs p and q of IndexPairs(), attribute(Left[p.Left])
+// <= attribute(Left[q.Left]) and attribute(Right[p.Right]) <=
+// attribute(Right[q.Right]) must both hold, so the attributes of the matched
+// elements never decrease along the alignment on either side; a NaN is in
+// order with no value. A match which would break the order with the matches
+// chosen before it is rejected even if the elements are the same, and the
+// result is the longest common subsequence satisfying the constraint, which
+// may be shorter than the unconstrained LCS. Instead of the memo table, whose
+// cells do not know the attributes of the matches they come from, the LCS is
+// a DP over the matches: each match extends the longest chain among the
+// matches above and to the left of it in the order of the attribute, which
+// takes O(K^2) time for K matching pairs of elements. Length() and the
+// methods built on IndexPairs() follow the constraint, while Table() and
+// SuffixTable() are still the memo tables of the unconstrained LCS. It is
+// ignored with WithMaxGap() and takes precedence over the other options
+// choosing the pairs, like WithBudget().
+func WithMonotoneAttribute(attribute func(interface{}) float64) Option {
+	return func(lcs *lcs) {
+		lcs.monotoneAttribute = attribute
+	}
+}
+
+// monotoneIndexPairsContext finds the pairs with a DP over the matches:
+// chain[k] is the length of the longest constrained chain ending at the k-th
+// match, and previous[k] is the match before it in the chain or -1.
+func (lcs *lcs) monotoneIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	leftAttributes := make([]float64, len(lcs.left))
+	for x, value := range lcs.left {
+		leftAttributes[x] = lcs.monotoneAttribute(value)
+	}
+	rightAttributes := make([]float64, len(lcs.right))
+	for y, value := range lcs.right {
+		rightAttributes[y] = lcs.monotoneAttribute(value)
+	}
+
+	matches := []IndexPair{}
+	chain := []int{}
+	previous := []int{}
+	best := -1
+	for y := 0; y < len(lcs.right); y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		// the matches of the rows before y, which are above every match in y
+		above := len(matches)
+		for x := 0; x < len(lcs.left); x++ {
+			if !lcs.match(x, y) {
+				continue
+			}
+			longest, from := 0, -1
+			for k := 0; k < above; k++ {
+				prev := matches[k]
+				if prev.Left < x && chain[k] > longest &&
+					leftAttributes[prev.Left] <= leftAttributes[x] &&
+					rightAttributes[prev.Right] <= rightAttributes[y] {
+					longest, from = chain[k], k
+				}
+			}
+			matches = append(matches, IndexPair{Left: x, Right: y})
+			chain = append(chain, longest+1)
+			previous = append(previous, from)
+			if best == -1 || chain[len(chain)-1] > chain[best] {
+				best = len(chain) - 1
+			}
+		}
+	}
+
+	if best == -1 {
+		return []IndexPair{}, nil
+	}
+	pairs := make([]IndexPair, chain[best])
+	for k, i := best, chain[best]-1; k != -1; k, i = previous[k], i-1 {
+		pairs[i] = matches[k]
+	}
+	return pairs, nil
+}
@@ -0,0 +1,102 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+type event struct {
+	name string
+	time float64
+}
+
+func TestWithMonotoneAttribute(t *testing.T) {
+	sameName := WithEqual(func(a, b interface{}) bool { return a.(event).name == b.(event).name })
+	eventTime := WithMonotoneAttribute(func(value interface{}) float64 { return value.(event).time })
+
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected []IndexPair
+	}{
+		{
+			left:     []interface{}{},
+			right:    []interface{}{event{"a", 1}},
+			expected: []IndexPair{},
+		},
+		{
+			left:     []interface{}{event{"a", 1}, event{"b", 2}, event{"c", 3}},
+			right:    []interface{}{event{"a", 1}, event{"b", 2}, event{"c", 3}},
+			expected: []IndexPair{{0, 0}, {1, 1}, {2, 2}},
+		},
+		// "c" of Right is logged before "b", so only one of them is aligned
+		{
+			left:     []interface{}{event{"a", 1}, event{"b", 2}, event{"c", 3}},
+			right:    []interface{}{event{"a", 1}, event{"b", 5}, event{"c", 4}},
+			expected: []IndexPair{{0, 0}, {1, 1}},
+		},
+		// the same times are in order
+		{
+			left:     []interface{}{event{"a", 1}, event{"b", 1}},
+			right:    []interface{}{event{"a", 2}, event{"b", 2}},
+			expected: []IndexPair{{0, 0}, {1, 1}},
+		},
+		// the order of Left is constrained as well
+		{
+			left:     []interface{}{event{"a", 9}, event{"b", 2}, event{"c", 3}},
+			right:    []interface{}{event{"a", 1}, event{"b", 2}, event{"c", 3}},
+			expected: []IndexPair{{1, 1}, {2, 2}},
+		},
+	}
+
+	for i, cs := range cases {
+		newLcs := New(cs.left, cs.right, sameName, eventTime)
+		pairs := newLcs.IndexPairs()
+		if !reflect.DeepEqual(pairs, cs.expected) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, pairs, cs.expected)
+		}
+		if length := newLcs.Length(); length != len(cs.expected) {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, length, len(cs.expected))
+		}
+	}
+
+	// compare with all the constrained chains
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(8), 3)
+		right := randomInts(random, random.Intn(8), 3)
+		// the values out of the order of their times
+		times := []float64{float64(random.Intn(3)), float64(random.Intn(3)), float64(random.Intn(3))}
+		attribute := func(value interface{}) float64 {
+			return times[value.(int)]
+		}
+		pairs := New(left, right, WithMonotoneAttribute(attribute)).IndexPairs()
+		checkCommonSubsequence(t, i, left, right, pairs)
+		for k := 1; k < len(pairs); k++ {
+			if attribute(left[pairs[k-1].Left]) > attribute(left[pairs[k].Left]) {
+				t.Errorf("test case %d failed at order, actual: %v", i, pairs)
+			}
+		}
+		if expected := longestMonotoneChain(left, right, attribute, -1, -1); len(pairs) != expected {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, len(pairs), expected)
+		}
+	}
+}
+
+// longestMonotoneChain is the longest constrained chain after the match of x and y.
+func longestMonotoneChain(left, right []interface{}, attribute func(interface{}) float64, x, y int) int {
+	longest := 0
+	for nextX := x + 1; nextX < len(left); nextX++ {
+		for nextY := y + 1; nextY < len(right); nextY++ {
+			if left[nextX] != right[nextY] {
+				continue
+			}
+			if x >= 0 && (attribute(left[x]) > attribute(left[nextX]) || attribute(right[y]) > attribute(right[nextY])) {
+				continue
+			}
+			longest = max(longest, 1+longestMonotoneChain(left, right, attribute, nextX, nextY))
+		}
+	}
+	return longest
+}
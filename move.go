@@ -0,0 +1,136 @@
+package golcs
+
+import "context"
+
+// minMoveSize is the smallest block reported by MoveMap(), as a single element
+// deleted in one place and inserted in another is more often a coincidence.
+const minMoveSize = 2
+
+// BlockMove is a block of elements deleted from Left and inserted in Right as
+// is. The ranges are half-open, like those of Opcode.
+type BlockMove struct {
+	LeftStart  int
+	LeftEnd    int
+	RightStart int
+	RightEnd   int
+}
+
+// MoveMap implements LCS.MoveMap()
+func (lcs *lcs) MoveMap() []BlockMove {
+	moves, _ := lcs.MoveMapContext(context.Background())
+	return moves
+}
+
+// MoveMapContext implements LCS.MoveMapContext()
+//
+// A moved block is a range of Left whose elements are all deleted by
+// EditScript() and a range of Right of the same size whose elements are all
+// inserted, where each element matches the one at the same offset with the
+// equality, wherever the two ranges are and whatever other changes are
+// around them. The moves are found longest first: the longest such pair of
+// ranges among the elements not moved yet is taken, and the search repeats
+// until no block of at least 2 elements is left. Each element is in one move
+// at most. Among the blocks of the same size, the one starting first in Left
+// is taken, then the one starting first in Right. Only exact blocks are
+// moves, so a block edited while moved is not one as a whole, though each of
+// its unchanged parts of at least 2 elements is a move of its own. The moves
+// are sorted by LeftStart. Each search visits only the elements deleted and
+// inserted and not moved yet, so it takes O(d*i) time for d of them in Left
+// and i in Right, plus O(m) to allocate the rows for a Left of size m.
+func (lcs *lcs) MoveMapContext(ctx context.Context) ([]BlockMove, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the indices of the elements which may be in a move
+	deleted := []int{}
+	for x, i := 0, 0; x < len(lcs.left); x++ {
+		if i < len(pairs) && pairs[i].Left == x {
+			i++
+		} else {
+			deleted = append(deleted, x)
+		}
+	}
+	inserted := []int{}
+	for y, i := 0, 0; y < len(lcs.right); y++ {
+		if i < len(pairs) && pairs[i].Right == y {
+			i++
+		} else {
+			inserted = append(inserted, y)
+		}
+	}
+
+	moves := []BlockMove{}
+	for {
+		move, err := lcs.longestMove(ctx, deleted, inserted)
+		if err != nil {
+			return nil, err
+		}
+		if move.LeftEnd-move.LeftStart < minMoveSize {
+			break
+		}
+		deleted = removeRange(deleted, move.LeftStart, move.LeftEnd)
+		inserted = removeRange(inserted, move.RightStart, move.RightEnd)
+		moves = append(moves, move)
+	}
+
+	for i := 1; i < len(moves); i++ {
+		for j := i; j > 0 && moves[j].LeftStart < moves[j-1].LeftStart; j-- {
+			moves[j], moves[j-1] = moves[j-1], moves[j]
+		}
+	}
+	if err := lcs.failed(); err != nil {
+		return nil, err
+	}
+	return moves, nil
+}
+
+// longestMove finds the longest block of the deleted elements matching one of
+// the inserted elements, visiting only them: run[x+1] is the length of the
+// block ending at x and the current y, and prev is the same for y-1.
+func (lcs *lcs) longestMove(ctx context.Context, deleted, inserted []int) (BlockMove, error) {
+	best := BlockMove{}
+	prev := make([]int, len(lcs.left)+1)
+	run := make([]int, len(lcs.left)+1)
+	previousY := -2
+	for _, y := range inserted {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return BlockMove{}, ctx.Err()
+		default:
+			// nop
+		}
+		for _, x := range deleted {
+			run[x+1] = 0
+			if !lcs.match(x, y) {
+				continue
+			}
+			run[x+1] = 1
+			if previousY == y-1 {
+				run[x+1] += prev[x]
+			}
+			length := run[x+1]
+			move := BlockMove{LeftStart: x + 1 - length, LeftEnd: x + 1, RightStart: y + 1 - length, RightEnd: y + 1}
+			bestLength := best.LeftEnd - best.LeftStart
+			if length > bestLength || (length == bestLength &&
+				(move.LeftStart < best.LeftStart || (move.LeftStart == best.LeftStart && move.RightStart < best.RightStart))) {
+				best = move
+			}
+		}
+		prev, run = run, prev
+		previousY = y
+	}
+	return best, nil
+}
+
+// removeRange removes the indices in [start, end) from ascending indices.
+func removeRange(indices []int, start, end int) []int {
+	kept := indices[:0]
+	for _, index := range indices {
+		if index < start || index >= end {
+			kept = append(kept, index)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,63 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveMap(t *testing.T) {
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected []BlockMove
+	}{
+		{
+			left:     []interface{}{},
+			right:    []interface{}{},
+			expected: []BlockMove{},
+		},
+		{
+			left:     []interface{}{"a", "b", "c"},
+			right:    []interface{}{"a", "b", "c"},
+			expected: []BlockMove{},
+		},
+		// the a block and the d block are moved, and the b block is edited
+		{
+			left:  []interface{}{"a1", "a2", "a3", "k", "b1", "b2", "b3", "m", "c1", "c2", "c3", "d1", "d2"},
+			right: []interface{}{"d1", "d2", "k", "c1", "c2", "c3", "m", "b1", "bX", "b3", "a1", "a2", "a3"},
+			expected: []BlockMove{
+				{LeftStart: 0, LeftEnd: 3, RightStart: 10, RightEnd: 13},
+				{LeftStart: 11, LeftEnd: 13, RightStart: 0, RightEnd: 2},
+			},
+		},
+		// a single element is not a block
+		{
+			left:     []interface{}{"a", "x", "y"},
+			right:    []interface{}{"x", "y", "a"},
+			expected: []BlockMove{},
+		},
+		// the longest block is taken before the first one
+		{
+			left:  []interface{}{"a", "b", "c", "w", "x", "y", "z"},
+			right: []interface{}{"w", "x", "y", "z", "a", "b", "a", "b", "c"},
+			expected: []BlockMove{
+				{LeftStart: 0, LeftEnd: 3, RightStart: 6, RightEnd: 9},
+			},
+		},
+		// the first of the blocks of the same size in Left
+		{
+			left:  []interface{}{"a", "b", "c", "x", "y", "z"},
+			right: []interface{}{"x", "y", "z", "b", "c", "a", "b"},
+			expected: []BlockMove{
+				{LeftStart: 0, LeftEnd: 2, RightStart: 5, RightEnd: 7},
+			},
+		},
+	}
+
+	for i, cs := range cases {
+		actual := New(cs.left, cs.right).MoveMap()
+		if !reflect.DeepEqual(actual, cs.expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, cs.expected)
+		}
+	}
+}
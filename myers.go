@@ -0,0 +1,115 @@
+package golcs
+
+import (
+	"context"
+	"time"
+)
+
+// WithBudget finds IndexPairs() with Myers' O(ND) algorithm, which explores
+// the paths of D = 0, 1, 2... edits until one reaches the ends of both arrays,
+// and stops it after the wall-clock budget d.
+//
+// When the budget runs out before the shortest edit script is found, the
+// calculation takes the path which got the furthest into the arrays and
+// deletes and inserts all the rest, so the result is still a valid common
+// subsequence and a valid diff, just maybe not the longest and the minimal
+// ones; Optimal() reports which. Length() is the length of the result in this
+// case. Table() and the methods on it still use the memo table. d <= 0
+// disables the option.
+func WithBudget(d time.Duration) Option {
+	return func(lcs *lcs) {
+		lcs.budget = d
+	}
+}
+
+// Optimal implements LCS.Optimal()
+func (lcs *lcs) Optimal() bool {
+	lcs.IndexPairs()
+	return !lcs.budgetExceeded
+}
+
+// myersIndexPairsContext finds the pairs with Myers' algorithm until the budget
+// runs out. v[offset+k] is the furthest x on the diagonal k = x - y.
+func (lcs *lcs) myersIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	deadline := time.Now().Add(lcs.budget)
+	sizeX, sizeY := len(lcs.left), len(lcs.right)
+	offset := sizeX + sizeY
+	v := make([]int, 2*offset+2)
+	// trace[d] is v[offset-d:offset+d+1] before exploring d edits, the only
+	// diagonals d edits extend, to backtrack the path in O(D^2) memory
+	trace := [][]int{}
+
+	// every path reaches the ends of the arrays within len(left)+len(right) edits
+	for d := 0; ; d++ {
+		select { // check in each d to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		if d > 0 && time.Now().After(deadline) {
+			// the furthest point explored with d-1 edits
+			bestX, bestY := 0, 0
+			for k := -(d - 1); k <= d-1; k += 2 {
+				x := v[offset+k]
+				y := x - k
+				if x <= sizeX && y >= 0 && y <= sizeY && x+y > bestX+bestY {
+					bestX, bestY = x, y
+				}
+			}
+			lcs.budgetExceeded = true
+			return myersBacktrack(trace, bestX, bestY), nil
+		}
+
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // insertion
+			} else {
+				x = v[offset+k-1] + 1 // deletion
+			}
+			y := x - k
+			for x < sizeX && y < sizeY && lcs.match(x, y) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= sizeX && y >= sizeY {
+				lcs.budgetExceeded = false
+				return myersBacktrack(trace, sizeX, sizeY), nil
+			}
+		}
+	}
+}
+
+// myersBacktrack collects the pairs on the diagonals of the path to x and y.
+// trace[d][d+k] is the furthest x on the diagonal k before exploring d edits.
+func myersBacktrack(trace [][]int, x, y int) []IndexPair {
+	pairs := []IndexPair{}
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		// the path of 0 edits starts at the beginning of the arrays
+		prevX, prevY := 0, 0
+		if d > 0 {
+			v := trace[d]
+			k := x - y
+			prevK := k - 1
+			if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+				prevK = k + 1
+			}
+			prevX = v[d+prevK]
+			prevY = prevX - prevK
+		}
+		for x > prevX && y > prevY {
+			x--
+			y--
+			pairs = append(pairs, IndexPair{Left: x, Right: y})
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+	return pairs
+}
@@ -0,0 +1,61 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithBudget(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+
+		newLcs := New(left, right, WithBudget(time.Minute))
+		pairs := newLcs.IndexPairs()
+		checkCommonSubsequence(t, i, left, right, pairs)
+		if expected := New(left, right).Length(); len(pairs) != expected || newLcs.Length() != expected {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, len(pairs), expected)
+		}
+		if !newLcs.Optimal() {
+			t.Errorf("test case %d failed at optimal", i)
+		}
+	}
+}
+
+func TestWithBudgetExceeded(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	left := append([]interface{}{-1, -2}, randomInts(random, 5000, 10)...)
+	right := append([]interface{}{-1, -2}, randomInts(random, 5000, 10)...)
+
+	newLcs := New(left, right, WithBudget(time.Nanosecond))
+	pairs := newLcs.IndexPairs()
+	checkCommonSubsequence(t, 0, left, right, pairs)
+	if newLcs.Optimal() {
+		t.Errorf("failed at optimal")
+	}
+	if len(pairs) < 2 || newLcs.Length() != len(pairs) {
+		t.Errorf("failed at length, actual: %v, %v", len(pairs), newLcs.Length())
+	}
+
+	// the edit script is still valid
+	applied, err := Apply(left, newLcs.EditScript())
+	if err != nil || !reflect.DeepEqual(applied, right) {
+		t.Errorf("failed at apply, error: %v", err)
+	}
+}
+
+// checkCommonSubsequence checks that pairs are increasing pairs of the same elements.
+func checkCommonSubsequence(t *testing.T, i int, left, right []interface{}, pairs []IndexPair) {
+	t.Helper()
+	for j, pair := range pairs {
+		if !reflect.DeepEqual(left[pair.Left], right[pair.Right]) {
+			t.Fatalf("test case %d failed at pair %d, not a match: %v", i, j, pair)
+		}
+		if j > 0 && (pair.Left <= pairs[j-1].Left || pair.Right <= pairs[j-1].Right) {
+			t.Fatalf("test case %d failed at pair %d, actual: %v after %v", i, j, pair, pairs[j-1])
+		}
+	}
+}
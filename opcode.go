@@ -0,0 +1,96 @@
+package golcs
+
+import "context"
+
+// WithPreferReplace pairs the deletions and insertions between two common
+// elements into a single OpcodeReplace in Opcodes(), as many GUI diff tools
+// present changes. The runs are paired even when their lengths differ, so a
+// replace opcode may cover a different number of elements in Left and Right.
+// The LCS itself is not affected, neither are Length() and EditScript().
+func WithPreferReplace() Option {
+	return func(lcs *lcs) {
+		lcs.preferReplace = true
+	}
+}
+
+// OpcodeTag represents the kind of an Opcode.
+type OpcodeTag int
+
+const (
+	// OpcodeEqual keeps a range of Left which is equal to the range of Right.
+	OpcodeEqual OpcodeTag = iota
+	// OpcodeReplace replaces a range of Left with a range of Right.
+	OpcodeReplace
+	// OpcodeDelete removes a range of Left.
+	OpcodeDelete
+	// OpcodeInsert adds a range of Right.
+	OpcodeInsert
+)
+
+// String returns the tag as named by difflib: "equal", "replace", "delete" or "insert".
+func (tag OpcodeTag) String() string {
+	switch tag {
+	case OpcodeEqual:
+		return "equal"
+	case OpcodeReplace:
+		return "replace"
+	case OpcodeDelete:
+		return "delete"
+	case OpcodeInsert:
+		return "insert"
+	}
+	return "unknown"
+}
+
+// Opcode is a step to transform Left[LeftStart:LeftEnd] into Right[RightStart:RightEnd].
+type Opcode struct {
+	Tag        OpcodeTag
+	LeftStart  int
+	LeftEnd    int
+	RightStart int
+	RightEnd   int
+}
+
+// Opcodes implements LCS.Opcodes()
+func (lcs *lcs) Opcodes() []Opcode {
+	opcodes, _ := lcs.OpcodesContext(context.Background())
+	return opcodes
+}
+
+// OpcodesContext implements LCS.OpcodesContext()
+//
+// The opcodes cover Left and Right from the start to the end without gaps,
+// like the ones of difflib. Each OpcodeEqual is a maximal run contiguous in
+// both arrays. Between two of them, the deletions come before the insertions
+// as separate opcodes, or as one OpcodeReplace with WithPreferReplace().
+func (lcs *lcs) OpcodesContext(ctx context.Context) ([]Opcode, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opcodes := []Opcode{}
+	x, y := 0, 0
+	runs := contiguousRuns(pairs)
+	for i := 0; i <= len(runs); i++ {
+		left, right := len(lcs.left), len(lcs.right)
+		if i < len(runs) {
+			left, right = runs[i][0].Left, runs[i][0].Right
+		}
+		if lcs.preferReplace && x < left && y < right {
+			opcodes = append(opcodes, Opcode{Tag: OpcodeReplace, LeftStart: x, LeftEnd: left, RightStart: y, RightEnd: right})
+		} else {
+			if x < left {
+				opcodes = append(opcodes, Opcode{Tag: OpcodeDelete, LeftStart: x, LeftEnd: left, RightStart: y, RightEnd: y})
+			}
+			if y < right {
+				opcodes = append(opcodes, Opcode{Tag: OpcodeInsert, LeftStart: left, LeftEnd: left, RightStart: y, RightEnd: right})
+			}
+		}
+		if i < len(runs) {
+			x, y = left+len(runs[i]), right+len(runs[i])
+			opcodes = append(opcodes, Opcode{Tag: OpcodeEqual, LeftStart: left, LeftEnd: x, RightStart: right, RightEnd: y})
+		}
+	}
+	return opcodes, nil
+}
@@ -0,0 +1,98 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOpcodes(t *testing.T) {
+	cases := []struct {
+		left          []interface{}
+		right         []interface{}
+		opcodes       []Opcode
+		replaceCodes  []Opcode
+		replaceLength int
+	}{
+		{
+			left:  []interface{}{1, 2, 3, 4, 5},
+			right: []interface{}{1, 6, 7, 4, 5},
+			opcodes: []Opcode{
+				{Tag: OpcodeEqual, LeftStart: 0, LeftEnd: 1, RightStart: 0, RightEnd: 1},
+				{Tag: OpcodeDelete, LeftStart: 1, LeftEnd: 3, RightStart: 1, RightEnd: 1},
+				{Tag: OpcodeInsert, LeftStart: 3, LeftEnd: 3, RightStart: 1, RightEnd: 3},
+				{Tag: OpcodeEqual, LeftStart: 3, LeftEnd: 5, RightStart: 3, RightEnd: 5},
+			},
+			replaceCodes: []Opcode{
+				{Tag: OpcodeEqual, LeftStart: 0, LeftEnd: 1, RightStart: 0, RightEnd: 1},
+				{Tag: OpcodeReplace, LeftStart: 1, LeftEnd: 3, RightStart: 1, RightEnd: 3},
+				{Tag: OpcodeEqual, LeftStart: 3, LeftEnd: 5, RightStart: 3, RightEnd: 5},
+			},
+			replaceLength: 3,
+		},
+		{
+			left:  []interface{}{"a", "b", "c", "d"},
+			right: []interface{}{"x", "b", "d", "y", "z"},
+			opcodes: []Opcode{
+				{Tag: OpcodeDelete, LeftStart: 0, LeftEnd: 1, RightStart: 0, RightEnd: 0},
+				{Tag: OpcodeInsert, LeftStart: 1, LeftEnd: 1, RightStart: 0, RightEnd: 1},
+				{Tag: OpcodeEqual, LeftStart: 1, LeftEnd: 2, RightStart: 1, RightEnd: 2},
+				{Tag: OpcodeDelete, LeftStart: 2, LeftEnd: 3, RightStart: 2, RightEnd: 2},
+				{Tag: OpcodeEqual, LeftStart: 3, LeftEnd: 4, RightStart: 2, RightEnd: 3},
+				{Tag: OpcodeInsert, LeftStart: 4, LeftEnd: 4, RightStart: 3, RightEnd: 5},
+			},
+			replaceCodes: []Opcode{
+				{Tag: OpcodeReplace, LeftStart: 0, LeftEnd: 1, RightStart: 0, RightEnd: 1},
+				{Tag: OpcodeEqual, LeftStart: 1, LeftEnd: 2, RightStart: 1, RightEnd: 2},
+				{Tag: OpcodeDelete, LeftStart: 2, LeftEnd: 3, RightStart: 2, RightEnd: 2},
+				{Tag: OpcodeEqual, LeftStart: 3, LeftEnd: 4, RightStart: 2, RightEnd: 3},
+				{Tag: OpcodeInsert, LeftStart: 4, LeftEnd: 4, RightStart: 3, RightEnd: 5},
+			},
+			replaceLength: 2,
+		},
+		{
+			left:  []interface{}{1, 2, 3},
+			right: []interface{}{4},
+			opcodes: []Opcode{
+				{Tag: OpcodeDelete, LeftStart: 0, LeftEnd: 3, RightStart: 0, RightEnd: 0},
+				{Tag: OpcodeInsert, LeftStart: 3, LeftEnd: 3, RightStart: 0, RightEnd: 1},
+			},
+			replaceCodes: []Opcode{
+				{Tag: OpcodeReplace, LeftStart: 0, LeftEnd: 3, RightStart: 0, RightEnd: 1},
+			},
+			replaceLength: 0,
+		},
+		{
+			left:          []interface{}{},
+			right:         []interface{}{},
+			opcodes:       []Opcode{},
+			replaceCodes:  []Opcode{},
+			replaceLength: 0,
+		},
+	}
+
+	for i, c := range cases {
+		actual := New(c.left, c.right).Opcodes()
+		if !reflect.DeepEqual(actual, c.opcodes) {
+			t.Errorf("test case %d failed at opcodes, actual: %v, expected: %v", i, actual, c.opcodes)
+		}
+
+		replaceLcs := New(c.left, c.right, WithPreferReplace())
+		actual = replaceLcs.Opcodes()
+		if !reflect.DeepEqual(actual, c.replaceCodes) {
+			t.Errorf("test case %d failed at replace opcodes, actual: %v, expected: %v", i, actual, c.replaceCodes)
+		}
+		if actual := replaceLcs.Length(); actual != c.replaceLength {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, c.replaceLength)
+		}
+	}
+}
+
+func TestOpcodeTagString(t *testing.T) {
+	tags := []OpcodeTag{OpcodeEqual, OpcodeReplace, OpcodeDelete, OpcodeInsert}
+	expected := []string{"equal", "replace", "delete", "insert"}
+	for i, tag := range tags {
+		if actual := tag.String(); actual != expected[i] {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, expected[i])
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package golcs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidOperations is returned by ApplyOperations when operations do not match the array.
+var ErrInvalidOperations = errors.New("golcs: operations do not match the array")
+
+// OperationKind represents the kind of an Operation.
+type OperationKind int
+
+const (
+	// OperationRetain keeps the next Count elements.
+	OperationRetain OperationKind = iota
+	// OperationInsert inserts Values at the current position.
+	OperationInsert
+	// OperationDelete removes the next Count elements.
+	OperationDelete
+)
+
+// Operation is a step of an operational transform over an array.
+type Operation struct {
+	Kind OperationKind
+	// Count is the number of the elements retained, inserted or deleted.
+	Count int
+	// Values are the inserted elements for OperationInsert, otherwise nil.
+	Values []interface{}
+}
+
+// Operations implements LCS.Operations()
+func (lcs *lcs) Operations() []Operation {
+	operations, _ := lcs.OperationsContext(context.Background())
+	return operations
+}
+
+// OperationsContext implements LCS.OperationsContext()
+//
+// The operations are EditScript() with consecutive edits of the same kind
+// merged, in the retain, insert and delete format of operational transforms:
+// a cursor walks Left from the start, a retain moves it over elements kept in
+// Right, a delete removes elements at it and an insert adds elements before
+// it. The counts of the retains and the deletes add up to len(Left), and
+// those of the retains and the inserts add up to len(Right), so a trailing
+// retain is never omitted. Between two retains, a delete comes before an
+// insert.
+func (lcs *lcs) OperationsContext(ctx context.Context) ([]Operation, error) {
+	edits, err := lcs.EditScriptContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []Operation{}
+	for _, edit := range edits {
+		kind := OperationRetain
+		switch edit.Kind {
+		case EditInsert:
+			kind = OperationInsert
+		case EditDelete:
+			kind = OperationDelete
+		}
+		if len(operations) == 0 || operations[len(operations)-1].Kind != kind {
+			operations = append(operations, Operation{Kind: kind})
+		}
+		last := &operations[len(operations)-1]
+		last.Count++
+		if kind == OperationInsert {
+			last.Values = append(last.Values, edit.Value)
+		}
+	}
+	return operations, nil
+}
+
+// ApplyOperations applies operations such as Operations() to an array.
+// It returns ErrInvalidOperations when the retains and the deletes do not
+// cover left exactly or an insert has a Count different from its Values.
+func ApplyOperations(left []interface{}, operations []Operation) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(left))
+	x := 0
+	for _, operation := range operations {
+		switch operation.Kind {
+		case OperationRetain, OperationDelete:
+			if operation.Count < 0 || x+operation.Count > len(left) {
+				return nil, ErrInvalidOperations
+			}
+			if operation.Kind == OperationRetain {
+				result = append(result, left[x:x+operation.Count]...)
+			}
+			x += operation.Count
+		case OperationInsert:
+			if operation.Count != len(operation.Values) {
+				return nil, ErrInvalidOperations
+			}
+			result = append(result, operation.Values...)
+		default:
+			return nil, ErrInvalidOperations
+		}
+	}
+	if x != len(left) {
+		return nil, ErrInvalidOperations
+	}
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestOperations(t *testing.T) {
+	left := []interface{}{"a", "b", "c", "d", "e"}
+	right := []interface{}{"a", "x", "y", "d", "e", "f"}
+
+	expected := []Operation{
+		{Kind: OperationRetain, Count: 1},
+		{Kind: OperationDelete, Count: 2},
+		{Kind: OperationInsert, Count: 2, Values: []interface{}{"x", "y"}},
+		{Kind: OperationRetain, Count: 2},
+		{Kind: OperationInsert, Count: 1, Values: []interface{}{"f"}},
+	}
+	operations := New(left, right).Operations()
+	if !reflect.DeepEqual(operations, expected) {
+		t.Errorf("actual: %v, expected: %v", operations, expected)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(20), 4)
+		right := randomInts(random, random.Intn(20), 4)
+		operations := New(left, right).Operations()
+
+		applied, err := ApplyOperations(left, operations)
+		if err != nil || !reflect.DeepEqual(applied, right) {
+			t.Errorf("test case %d failed at apply, actual: %v, %v, expected: %v", i, applied, err, right)
+		}
+		counts := map[OperationKind]int{}
+		for _, operation := range operations {
+			counts[operation.Kind] += operation.Count
+		}
+		if counts[OperationRetain]+counts[OperationDelete] != len(left) || counts[OperationRetain]+counts[OperationInsert] != len(right) {
+			t.Errorf("test case %d failed at counts, actual: %v", i, counts)
+		}
+	}
+}
+
+func TestApplyOperationsInvalid(t *testing.T) {
+	left := []interface{}{1, 2, 3}
+	cases := [][]Operation{
+		{{Kind: OperationRetain, Count: 2}},
+		{{Kind: OperationRetain, Count: 2}, {Kind: OperationDelete, Count: 2}},
+		{{Kind: OperationRetain, Count: 3}, {Kind: OperationInsert, Count: 2, Values: []interface{}{4}}},
+		{{Kind: OperationRetain, Count: -1}, {Kind: OperationRetain, Count: 4}},
+		{{Kind: OperationKind(9), Count: 3}},
+	}
+	for i, operations := range cases {
+		if _, err := ApplyOperations(left, operations); err != ErrInvalidOperations {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, err, ErrInvalidOperations)
+		}
+	}
+}
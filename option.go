@@ -0,0 +1,277 @@
+package golcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Option configures an LCS calculator created by New.
+type Option func(*lcs)
+
+// WithEqual sets the function to compare elements of the two arrays.
+// The function is called with an element of Left as a and one of Right as b.
+func WithEqual(equal func(a, b interface{}) bool) Option {
+	return func(lcs *lcs) {
+		lcs.equal = equal
+		lcs.customEqual = true
+	}
+}
+
+// WithEqualErr sets a function to compare elements which may fail, for
+// example by parsing them. It is called like the one of WithEqual().
+//
+// When the function returns an error, the elements are treated as different
+// and the context aware methods, such as TableContext(), LengthContext() and
+// IndexPairsContext(), return the first error instead of a result; those
+// filling the memo table stop at its next row. The result is never cached,
+// and the calculator keeps returning the error from then on. The methods
+// without a context cannot report it and return empty results such as nil and
+// 0 instead, so use the context aware versions with this option.
+func WithEqualErr(equal func(a, b interface{}) (bool, error)) Option {
+	return func(lcs *lcs) {
+		lcs.equal = func(a, b interface{}) bool {
+			same, err := equal(a, b)
+			if err != nil {
+				lcs.fail(err)
+				return false
+			}
+			return same
+		}
+		lcs.customEqual = true
+	}
+}
+
+// WithTypeComparators compares elements with the comparator registered for
+// the dynamic type of the element of Left, so the elements of a heterogeneous
+// array can each have their own equality. The element of Right is given to
+// the comparator as is even when its type is different, so a comparator
+// should check the type of b before using it. Elements of Left of a type not
+// in comparators, including nil, are compared with the equality set before
+// this option, reflect.DeepEqual by default. The map is copied.
+func WithTypeComparators(comparators map[reflect.Type]func(a, b interface{}) bool) Option {
+	registry := make(map[reflect.Type]func(a, b interface{}) bool, len(comparators))
+	for t, comparator := range comparators {
+		registry[t] = comparator
+	}
+	return func(lcs *lcs) {
+		fallback := lcs.equal
+		lcs.equal = func(a, b interface{}) bool {
+			if comparator, ok := registry[reflect.TypeOf(a)]; ok {
+				return comparator(a, b)
+			}
+			return fallback(a, b)
+		}
+		lcs.customEqual = true
+	}
+}
+
+// WithNoCache drops the memo tables once IndexPairs() is calculated.
+//
+// By default, the memo table of O(mn) memory is kept as long as the LCS
+// calculator lives, so that Table() and the methods built on it are fast when
+// called again. With this option, only the small results such as the pairs
+// and the values are kept after the pairs are found, which saves memory for
+// one-shot calculations: the memo tables, the results of WithAsyncEqual(),
+// the entries of WithComparisonCache() and the outcomes remembered by
+// WithComparisonTimeout() are all released. A later call of a method which
+// needs the table, such as Table() or SensitivityByIndex(), calculates it
+// again from scratch, comparing the elements again, so with
+// WithComparisonTimeout() it may see other timeouts than IndexPairs() did.
+func WithNoCache() Option {
+	return func(lcs *lcs) {
+		lcs.noCache = true
+	}
+}
+
+// WithJSONEquality compares elements as values decoded by encoding/json.
+//
+// Maps and slices are compared recursively like reflect.DeepEqual, so the key
+// order of objects never matters. Numbers are normalized before comparison:
+// every integer, float and json.Number is converted to float64, so 1, int64(1),
+// 1.0 and json.Number("1") are all equal, and NaN is equal to NaN. Integers
+// beyond 2^53 lose their precision in the conversion, except json.Number
+// values with the same text which are always equal.
+func WithJSONEquality() Option {
+	return WithEqual(jsonEqual)
+}
+
+// WithNormalizeNewlines makes "\r\n" and a lone "\r" in string elements
+// equivalent to "\n" when elements are compared. The elements themselves keep
+// the original line terminators in Values() and the other results.
+//
+// Every "\r\n" is replaced with "\n" first, and then every remaining "\r" is
+// replaced with "\n", so "a\r\n", "a\r" and "a\n" are the same while "a" without
+// any terminator is still different from them. NewLines() with this option
+// also splits lines at a lone "\r".
+func WithNormalizeNewlines() Option {
+	return func(lcs *lcs) {
+		lcs.normalizeNewlines = true
+		lcs.transforms = append(lcs.transforms, normalizeNewlines)
+	}
+}
+
+// WithNormalizers compares elements after applying the normalizers, for
+// example lower-casing and trimming spaces, instead of the elements
+// themselves. The normalizers are applied to each element once in the given
+// order, the output of one being the input of the next, and also after the
+// normalizers of the options given before. They are used only to compare
+// elements: Values() and the other results still have the original elements.
+func WithNormalizers(normalizers ...func(interface{}) interface{}) Option {
+	return func(lcs *lcs) {
+		lcs.transforms = append(lcs.transforms, normalizers...)
+	}
+}
+
+// WithStringEquality compares elements by their text instead of their values.
+//
+// Each element is formatted with fmt.Sprint, which uses the String() method of
+// a fmt.Stringer, and then normalize is applied to the text if it is not nil.
+// Two elements are the same when their texts are the same, so 1, int64(1) and
+// "1" are all the same even though reflect.DeepEqual says they are not. The
+// texts are made once for each element and then replace the elements in every
+// comparison: an equality given with WithEqual() receives the texts.
+func WithStringEquality(normalize func(string) string) Option {
+	return func(lcs *lcs) {
+		lcs.transforms = append(lcs.transforms, func(value interface{}) interface{} {
+			text := fmt.Sprint(value)
+			if normalize != nil {
+				text = normalize(text)
+			}
+			return text
+		})
+	}
+}
+
+// WithPrefixMatch compares strings as paths split by sep: two strings are the
+// same when they are equal or one of them is a prefix of the other at a
+// separator boundary, that is, the rest of the longer one starts with sep, or
+// the prefix itself ends with sep. For example, with sep "/", "a/b" is the same
+// as "a/b/c" and "a/" but not as "a/bc". An empty sep allows any prefix.
+// Elements other than strings are compared with reflect.DeepEqual.
+//
+// The equality is not transitive: "a" is the same as both "a/b" and "a/c",
+// which are not the same as each other. Every comparison is done between an
+// element of Left and one of Right, so the memo table and the backtracking see
+// the same answers, but an element may be matched with any of the paths under
+// it. This option replaces an equality given with WithEqual().
+func WithPrefixMatch(sep string) Option {
+	return WithEqual(func(a, b interface{}) bool {
+		textA, okA := a.(string)
+		textB, okB := b.(string)
+		if !okA || !okB {
+			return reflect.DeepEqual(a, b)
+		}
+		if len(textA) > len(textB) {
+			textA, textB = textB, textA
+		}
+		if !strings.HasPrefix(textB, textA) {
+			return false
+		}
+		return len(textA) == len(textB) || strings.HasPrefix(textB[len(textA):], sep) || strings.HasSuffix(textA, sep)
+	})
+}
+
+// WithNumericBinning compares numbers by the buckets of bucketSize they fall
+// in, so that near-equal floats match. Values() and the other results still
+// have the original numbers.
+//
+// The bucket of a number v is floor(v / bucketSize): the buckets are half-open
+// ranges [k*bucketSize, (k+1)*bucketSize), so two numbers closer than
+// bucketSize may still be in different buckets across a boundary, for example
+// 0.99 and 1.01 with a bucketSize of 1. Integers and floats of all kinds share
+// the buckets. Elements other than numbers are compared as without the
+// option, and never match a number. bucketSize <= 0 disables the option.
+func WithNumericBinning(bucketSize float64) Option {
+	return func(lcs *lcs) {
+		if bucketSize <= 0 {
+			return
+		}
+		lcs.transforms = append(lcs.transforms, func(value interface{}) interface{} {
+			var number float64
+			switch v := reflect.ValueOf(value); v.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				number = float64(v.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				number = float64(v.Uint())
+			case reflect.Float32, reflect.Float64:
+				number = v.Float()
+			default:
+				return value
+			}
+			return numericBin(math.Floor(number / bucketSize))
+		})
+	}
+}
+
+// numericBin is the bucket of a number given by WithNumericBinning().
+type numericBin float64
+
+func normalizeNewlines(value interface{}) interface{} {
+	if text, ok := value.(string); ok {
+		return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	}
+	return value
+}
+
+func jsonEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, valueA := range a {
+			valueB, ok := b[key]
+			if !ok || !jsonEqual(valueA, valueB) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		if b, ok := b.(json.Number); ok && a == b {
+			return true
+		}
+	}
+
+	numberA, okA := jsonNumber(a)
+	numberB, okB := jsonNumber(b)
+	if okA || okB {
+		if !okA || !okB {
+			return false
+		}
+		return numberA == numberB || (math.IsNaN(numberA) && math.IsNaN(numberB))
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// jsonNumber converts a numeric value into float64.
+func jsonNumber(value interface{}) (float64, bool) {
+	if number, ok := value.(json.Number); ok {
+		float, err := number.Float64()
+		return float, err == nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
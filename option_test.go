@@ -0,0 +1,428 @@
+package golcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithEqual(t *testing.T) {
+	newLcs := New(
+		[]interface{}{"Foo", "bar", "BAZ"},
+		[]interface{}{"foo", "qux", "baz"},
+		WithEqual(func(a, b interface{}) bool {
+			return strings.EqualFold(a.(string), b.(string))
+		}),
+	)
+
+	expectedPairs := []IndexPair{{0, 0}, {2, 2}}
+	if !reflect.DeepEqual(newLcs.IndexPairs(), expectedPairs) {
+		t.Errorf("failed at index pairs, actual: %#v, expected: %#v", newLcs.IndexPairs(), expectedPairs)
+	}
+	if newLcs.Length() != 2 {
+		t.Errorf("failed at length, actual: %d, expected: %d", newLcs.Length(), 2)
+	}
+}
+
+func TestWithEqualErr(t *testing.T) {
+	errParse := errors.New("cannot parse")
+	calls := 0
+	parseEqual := func(a, b interface{}) (bool, error) {
+		calls++
+		var numberA, numberB int
+		if _, err := fmt.Sscan(a.(string), &numberA); err != nil {
+			return false, errParse
+		}
+		if _, err := fmt.Sscan(b.(string), &numberB); err != nil {
+			return false, errParse
+		}
+		return numberA == numberB, nil
+	}
+
+	left := []interface{}{"1", "02", "3", "4"}
+	newLcs := New(left, []interface{}{"01", "2", "4"}, WithEqualErr(parseEqual))
+	pairs, err := newLcs.IndexPairsContext(context.Background())
+	if expected := []IndexPair{{0, 0}, {1, 1}, {3, 2}}; err != nil || !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("failed at index pairs, actual: %v, %v, expected: %v", pairs, err, expected)
+	}
+
+	right := []interface{}{"1", "x", "3", "4", "5", "6"}
+	calls = 0
+	newLcs = New(left, right, WithEqualErr(parseEqual))
+	if table, err := newLcs.TableContext(context.Background()); table != nil || err != errParse {
+		t.Errorf("failed at table, actual: %v, %v, expected: %v", table, err, errParse)
+	}
+	// the table stops at the row after the failure
+	if expected := 2 * len(left); calls != expected {
+		t.Errorf("failed at calls, actual: %d, expected: %d", calls, expected)
+	}
+	if pairs, err := newLcs.IndexPairsContext(context.Background()); pairs != nil || err != errParse {
+		t.Errorf("failed at index pairs, actual: %v, %v, expected: %v", pairs, err, errParse)
+	}
+	if length, err := New(left, right, WithEqualErr(parseEqual)).LengthContext(context.Background()); length != 0 || err != errParse {
+		t.Errorf("failed at length, actual: %v, %v, expected: %v", length, err, errParse)
+	}
+}
+
+func TestWithJSONEquality(t *testing.T) {
+	decode := func(src string) []interface{} {
+		var values []interface{}
+		if err := json.Unmarshal([]byte(src), &values); err != nil {
+			t.Fatal(err)
+		}
+		return values
+	}
+
+	cases := []struct {
+		left   []interface{}
+		right  []interface{}
+		length int
+	}{
+		{
+			left:   decode(`[{"a": 1, "b": [1, 2]}, "x", 3]`),
+			right:  decode(`[{"b": [1.0, 2e0], "a": 1.00}, "y", 3]`),
+			length: 2,
+		},
+		{
+			left:   []interface{}{1, int64(2), uint8(3), float32(4)},
+			right:  decode(`[1, 2, 3, 4]`),
+			length: 4,
+		},
+		{
+			left:   []interface{}{json.Number("1"), json.Number("12345678901234567890")},
+			right:  []interface{}{1.0, json.Number("12345678901234567890")},
+			length: 2,
+		},
+		{
+			left:   []interface{}{math.NaN(), map[string]interface{}{"n": math.NaN()}},
+			right:  []interface{}{math.NaN(), map[string]interface{}{"n": math.NaN()}},
+			length: 2,
+		},
+		{
+			left:   []interface{}{1, "1", true, nil},
+			right:  []interface{}{"1", 0, false, "true"},
+			length: 1,
+		},
+	}
+
+	for i, c := range cases {
+		actualLength := New(c.left, c.right, WithJSONEquality()).Length()
+		if actualLength != c.length {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, actualLength, c.length)
+		}
+	}
+}
+
+func TestWithNormalizeNewlines(t *testing.T) {
+	cases := []struct {
+		left   string
+		right  string
+		length int
+		values []interface{}
+	}{
+		{
+			left:   "a\r\nb\r\nc\r\n",
+			right:  "a\nb\nc\n",
+			length: 3,
+			values: []interface{}{"a\r\n", "b\r\n", "c\r\n"},
+		},
+		{
+			left:   "a\rb\rc\r",
+			right:  "a\r\nb\nc\r",
+			length: 3,
+			values: []interface{}{"a\r", "b\r", "c\r"},
+		},
+		{
+			left:   "a\r\nb",
+			right:  "a\nb\n",
+			length: 1,
+			values: []interface{}{"a\r\n"},
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := NewLines(c.left, c.right, WithNormalizeNewlines())
+		if actual := newLcs.Length(); actual != c.length {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, actual, c.length)
+		}
+		if actual := newLcs.Values(); !reflect.DeepEqual(actual, c.values) {
+			t.Errorf("test case %d failed at values, actual: %#v, expected: %#v", i, actual, c.values)
+		}
+		if actual := NewLines(c.left, c.right).Length(); actual != 0 {
+			t.Errorf("test case %d failed at length without the option, actual: %d", i, actual)
+		}
+	}
+}
+
+type testStringer struct {
+	name string
+	id   int
+}
+
+func (s testStringer) String() string {
+	return s.name
+}
+
+func TestWithStringEquality(t *testing.T) {
+	cases := []struct {
+		left      []interface{}
+		right     []interface{}
+		normalize func(string) string
+		length    int
+	}{
+		{
+			left:   []interface{}{1, int64(2), 3.5, true},
+			right:  []interface{}{"1", uint8(2), "3.5", "true"},
+			length: 4,
+		},
+		{
+			left:   []interface{}{testStringer{"a", 1}, testStringer{"b", 2}},
+			right:  []interface{}{testStringer{"a", 3}, "b"},
+			length: 2,
+		},
+		{
+			left:      []interface{}{"  Foo", "BAR "},
+			right:     []interface{}{"foo", "bar"},
+			normalize: func(s string) string { return strings.ToLower(strings.TrimSpace(s)) },
+			length:    2,
+		},
+	}
+
+	for i, c := range cases {
+		if actual := New(c.left, c.right).Length(); actual != 0 {
+			t.Errorf("test case %d failed at structural length, actual: %d", i, actual)
+		}
+		newLcs := New(c.left, c.right, WithStringEquality(c.normalize))
+		if actual := newLcs.Length(); actual != c.length {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, actual, c.length)
+		}
+		if actual := newLcs.Values(); !reflect.DeepEqual(actual, c.left[:c.length]) {
+			t.Errorf("test case %d failed at values, actual: %#v", i, actual)
+		}
+	}
+}
+
+func TestWithNoCache(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 1000, 10)
+	right := randomInts(random, 1000, 10)
+
+	heapAfterValues := func(opts ...Option) (int64, LCS) {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		newLcs := New(left, right, opts...)
+		newLcs.Values()
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		return int64(after.HeapAlloc) - int64(before.HeapAlloc), newLcs
+	}
+
+	cached, cachedLcs := heapAfterValues()
+	uncached, uncachedLcs := heapAfterValues(WithNoCache())
+	// the table of 1001 * 1001 ints is about 8MB
+	if cached < 4<<20 || uncached > 1<<20 {
+		t.Errorf("unexpected heap usage, cached: %d, uncached: %d", cached, uncached)
+	}
+	if uncachedLcs.(*lcs).table != nil {
+		t.Errorf("the table is kept")
+	}
+	if !reflect.DeepEqual(uncachedLcs.IndexPairs(), cachedLcs.IndexPairs()) {
+		t.Errorf("failed at index pairs")
+	}
+	if !reflect.DeepEqual(uncachedLcs.(*lcs).Table(), cachedLcs.(*lcs).Table()) {
+		t.Errorf("failed to calculate the table again")
+	}
+}
+
+func TestWithNoCacheReleasesStates(t *testing.T) {
+	left := []interface{}{"a", "b", "c"}
+	right := []interface{}{"a", "c"}
+	equal := func(ctx context.Context, a, b interface{}) (bool, error) {
+		return a == b, nil
+	}
+	newLcs := New(left, right,
+		WithNoCache(),
+		WithAsyncEqual(equal, 2),
+		WithComparisonCache(),
+	).(*lcs)
+	newLcs.Table()
+	if _, err := newLcs.IndexPairsContext(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if newLcs.table != nil || newLcs.suffixTable != nil || newLcs.partialTable != nil {
+		t.Errorf("failed at the tables")
+	}
+	if newLcs.asyncMatches != nil {
+		t.Errorf("failed at the async matches")
+	}
+	if len(newLcs.comparisonCache) != 0 {
+		t.Errorf("failed at the comparison cache, actual: %v", newLcs.comparisonCache)
+	}
+	if actual := newLcs.Length(); actual != 2 {
+		t.Errorf("failed at length, actual: %v", actual)
+	}
+
+	timed := New(left, right, WithNoCache(), WithComparisonTimeout(time.Second, nil)).(*lcs)
+	timed.IndexPairs()
+	if timed.decided != nil || timed.decisions != nil {
+		t.Errorf("failed at the timeout decisions")
+	}
+}
+
+func TestWithNormalizers(t *testing.T) {
+	lower := func(value interface{}) interface{} { return strings.ToLower(value.(string)) }
+	collapse := func(value interface{}) interface{} { return strings.Join(strings.Fields(value.(string)), " ") }
+
+	left := []interface{}{"Hello  World", "foo", "Bar baz"}
+	right := []interface{}{"hello world", "Foo", "bar  BAZ"}
+
+	cases := []struct {
+		normalizers []func(interface{}) interface{}
+		pairs       []IndexPair
+	}{
+		{normalizers: nil, pairs: []IndexPair{}},
+		{normalizers: []func(interface{}) interface{}{lower}, pairs: []IndexPair{{1, 1}}},
+		{normalizers: []func(interface{}) interface{}{collapse}, pairs: []IndexPair{}},
+		{normalizers: []func(interface{}) interface{}{lower, collapse}, pairs: []IndexPair{{0, 0}, {1, 1}, {2, 2}}},
+	}
+
+	for i, c := range cases {
+		newLcs := New(left, right, WithNormalizers(c.normalizers...))
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+	}
+
+	values := New(left, right, WithNormalizers(lower, collapse)).Values()
+	if expected := []interface{}{"Hello  World", "foo", "Bar baz"}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("failed at values, actual: %#v, expected: %#v", values, expected)
+	}
+}
+
+func TestWithPrefixMatch(t *testing.T) {
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+		sep   string
+		pairs []IndexPair
+		plain []IndexPair
+	}{
+		{
+			left:  []interface{}{"src", "src/a.go", "docs/x.md"},
+			right: []interface{}{"src/a.go", "src/b.go", "docs"},
+			sep:   "/",
+			pairs: []IndexPair{{Left: 0, Right: 1}, {Left: 2, Right: 2}},
+			plain: []IndexPair{{Left: 1, Right: 0}},
+		},
+		{
+			// "src" is not a prefix of "srcs" at a boundary
+			left:  []interface{}{"srcs/a", "lib/"},
+			right: []interface{}{"src", "lib/b"},
+			sep:   "/",
+			pairs: []IndexPair{{Left: 1, Right: 1}},
+			plain: []IndexPair{},
+		},
+		{
+			left:  []interface{}{"a.b.c", 1, "x"},
+			right: []interface{}{"a.b", 1, "x.y"},
+			sep:   ".",
+			pairs: []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}, {Left: 2, Right: 2}},
+			plain: []IndexPair{{Left: 1, Right: 1}},
+		},
+	}
+
+	for i, c := range cases {
+		if actual := New(c.left, c.right, WithPrefixMatch(c.sep)).IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+		if actual := New(c.left, c.right).IndexPairs(); !reflect.DeepEqual(actual, c.plain) {
+			t.Errorf("test case %d failed at plain index pairs, actual: %v, expected: %v", i, actual, c.plain)
+		}
+	}
+}
+
+func TestWithTypeComparators(t *testing.T) {
+	type point struct{ x, y float64 }
+	comparators := map[reflect.Type]func(a, b interface{}) bool{
+		reflect.TypeOf(""): func(a, b interface{}) bool {
+			text, ok := b.(string)
+			return ok && strings.EqualFold(a.(string), text)
+		},
+		reflect.TypeOf(point{}): func(a, b interface{}) bool {
+			other, ok := b.(point)
+			return ok && math.Abs(a.(point).x-other.x) < 0.01 && math.Abs(a.(point).y-other.y) < 0.01
+		},
+	}
+
+	left := []interface{}{"Foo", point{1, 2}, 3, "bar", 4.0, nil}
+	right := []interface{}{"foo", point{1.001, 2}, 3, "BAZ", 4, nil}
+
+	newLcs := New(left, right, WithTypeComparators(comparators))
+	expected := []IndexPair{{0, 0}, {1, 1}, {2, 2}, {5, 5}}
+	if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at index pairs, actual: %v, expected: %v", actual, expected)
+	}
+
+	// the types without comparators fall back to the equality set before
+	newLcs = New(left, right, WithEqual(func(a, b interface{}) bool {
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}), WithTypeComparators(comparators))
+	expected = []IndexPair{{0, 0}, {1, 1}, {2, 2}, {4, 4}, {5, 5}}
+	if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at fallback index pairs, actual: %v, expected: %v", actual, expected)
+	}
+}
+
+func TestWithNumericBinning(t *testing.T) {
+	cases := []struct {
+		left       []interface{}
+		right      []interface{}
+		bucketSize float64
+		values     []interface{}
+	}{
+		{
+			left:       []interface{}{1.02, 2.51, 3.98, 5.5},
+			right:      []interface{}{1.04, 2.49, 3.91, 5.2},
+			bucketSize: 0.1,
+			values:     []interface{}{1.02, 3.98},
+		},
+		{
+			left:       []interface{}{1.02, 2.51, 3.98, 5.5},
+			right:      []interface{}{1.04, 2.49, 3.91, 5.2},
+			bucketSize: 1,
+			values:     []interface{}{1.02, 2.51, 3.98, 5.5},
+		},
+		{
+			// integers and floats share the buckets, other elements are not binned
+			left:       []interface{}{10, "a", 20.5, "b"},
+			right:      []interface{}{14.9, "a", int64(24), "c"},
+			bucketSize: 5,
+			values:     []interface{}{10, "a", 20.5},
+		},
+		{
+			left:       []interface{}{1.02, 2.51},
+			right:      []interface{}{1.04, 2.51},
+			bucketSize: 0,
+			values:     []interface{}{2.51},
+		},
+	}
+
+	for i, c := range cases {
+		if actual := New(c.left, c.right).Length(); actual == len(c.values) && c.bucketSize > 0 {
+			t.Errorf("test case %d failed at exact length, actual: %d", i, actual)
+		}
+		if actual := New(c.left, c.right, WithNumericBinning(c.bucketSize)).Values(); !reflect.DeepEqual(actual, c.values) {
+			t.Errorf("test case %d failed at values, actual: %v, expected: %v", i, actual, c.values)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package golcs
+
+import "context"
+
+// Partition implements LCS.Partition()
+func (lcs *lcs) Partition() (common, onlyLeft, onlyRight []interface{}) {
+	common, onlyLeft, onlyRight, _ = lcs.PartitionContext(context.Background())
+	return common, onlyLeft, onlyRight
+}
+
+// PartitionContext implements LCS.PartitionContext()
+//
+// common is the same as Values(). onlyLeft and onlyRight are the elements of
+// Left and Right not in the LCS, each in its original order. common and
+// onlyLeft together have the same elements as Left, but their concatenation
+// is not Left itself when the unmatched elements are interleaved with the
+// matched ones; the same applies to Right. Use EditScript() to keep the order.
+func (lcs *lcs) PartitionContext(ctx context.Context) (common, onlyLeft, onlyRight []interface{}, err error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	common = make([]interface{}, 0, len(pairs))
+	onlyLeft = make([]interface{}, 0, len(lcs.left)-len(pairs))
+	onlyRight = make([]interface{}, 0, len(lcs.right)-len(pairs))
+	x, y := 0, 0
+	for _, pair := range pairs {
+		onlyLeft = append(onlyLeft, lcs.left[x:pair.Left]...)
+		onlyRight = append(onlyRight, lcs.right[y:pair.Right]...)
+		common = append(common, lcs.left[pair.Left])
+		x, y = pair.Left+1, pair.Right+1
+	}
+	onlyLeft = append(onlyLeft, lcs.left[x:]...)
+	onlyRight = append(onlyRight, lcs.right[y:]...)
+
+	return common, onlyLeft, onlyRight, nil
+}
@@ -0,0 +1,71 @@
+package golcs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	cases := []struct {
+		left      []interface{}
+		right     []interface{}
+		common    []interface{}
+		onlyLeft  []interface{}
+		onlyRight []interface{}
+	}{
+		{
+			left:      []interface{}{1, 2, 5, 3, 1, 1, 5, 8, 3},
+			right:     []interface{}{1, 2, 3, 3, 4, 4, 5, 1, 6},
+			common:    []interface{}{1, 2, 5, 1},
+			onlyLeft:  []interface{}{3, 1, 5, 8, 3},
+			onlyRight: []interface{}{3, 3, 4, 4, 6},
+		},
+		{
+			left:      []interface{}{},
+			right:     []interface{}{1, 2},
+			common:    []interface{}{},
+			onlyLeft:  []interface{}{},
+			onlyRight: []interface{}{1, 2},
+		},
+		{
+			left:      []interface{}{"a", "b"},
+			right:     []interface{}{"a", "b"},
+			common:    []interface{}{"a", "b"},
+			onlyLeft:  []interface{}{},
+			onlyRight: []interface{}{},
+		},
+	}
+
+	sorted := func(values ...[]interface{}) []string {
+		result := []string{}
+		for _, v := range values {
+			for _, value := range v {
+				result = append(result, fmt.Sprint(value))
+			}
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	for i, c := range cases {
+		common, onlyLeft, onlyRight := New(c.left, c.right).Partition()
+		if !reflect.DeepEqual(common, c.common) {
+			t.Errorf("test case %d failed at common, actual: %#v, expected: %#v", i, common, c.common)
+		}
+		if !reflect.DeepEqual(onlyLeft, c.onlyLeft) {
+			t.Errorf("test case %d failed at only left, actual: %#v, expected: %#v", i, onlyLeft, c.onlyLeft)
+		}
+		if !reflect.DeepEqual(onlyRight, c.onlyRight) {
+			t.Errorf("test case %d failed at only right, actual: %#v, expected: %#v", i, onlyRight, c.onlyRight)
+		}
+
+		if !reflect.DeepEqual(sorted(common, onlyLeft), sorted(c.left)) {
+			t.Errorf("test case %d failed to reconstruct left", i)
+		}
+		if !reflect.DeepEqual(sorted(common, onlyRight), sorted(c.right)) {
+			t.Errorf("test case %d failed to reconstruct right", i)
+		}
+	}
+}
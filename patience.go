@@ -0,0 +1,208 @@
+package golcs
+
+import (
+	"context"
+	"sort"
+)
+
+// WithPatience finds IndexPairs() with the patience diff, which aligns the
+// elements appearing exactly once in both Left and Right first, such as
+// unique lines of code, and only then the rest between them.
+//
+// The elements in common at the start and the end are matched first. Then
+// the elements unique in both arrays are matched along the longest increasing
+// subsequence of their positions, which is the patience sorting the algorithm
+// is named after, and each range between two of them is diffed the same way.
+// A range without unique elements is diffed with the memo table. It keeps
+// frequent elements like blank lines and braces from pulling the alignment
+// apart, so the diff is often easier to read, but the result is not always
+// the longest common subsequence; Length() is the length of the result.
+// Only booleans, numbers and strings are compared as unique elements, and
+// none of them are with WithEqual() and the other options changing the
+// equality, which leaves the memo table for the whole arrays.
+func WithPatience() Option {
+	return func(lcs *lcs) {
+		lcs.patience = true
+	}
+}
+
+// patienceIndexPairsContext finds the pairs with the patience diff.
+func (lcs *lcs) patienceIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	pairs := []IndexPair{}
+	if err := lcs.patienceRange(ctx, 0, len(lcs.left), 0, len(lcs.right), &pairs); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// patienceRange appends the pairs of the patience diff of left[x0:x1] and
+// right[y0:y1] to pairs.
+func (lcs *lcs) patienceRange(ctx context.Context, x0, x1, y0, y1 int, pairs *[]IndexPair) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		// nop
+	}
+
+	for x0 < x1 && y0 < y1 && lcs.match(x0, y0) {
+		*pairs = append(*pairs, IndexPair{Left: x0, Right: y0})
+		x0++
+		y0++
+	}
+	suffix := 0
+	for x1-suffix > x0 && y1-suffix > y0 && lcs.match(x1-suffix-1, y1-suffix-1) {
+		suffix++
+	}
+	x1, y1 = x1-suffix, y1-suffix
+
+	anchors := lcs.patienceAnchors(x0, x1, y0, y1)
+	if len(anchors) == 0 {
+		if err := lcs.rangeIndexPairsContext(ctx, x0, x1, y0, y1, pairs); err != nil {
+			return err
+		}
+	} else {
+		for _, anchor := range anchors {
+			if err := lcs.patienceRange(ctx, x0, anchor.Left, y0, anchor.Right, pairs); err != nil {
+				return err
+			}
+			*pairs = append(*pairs, anchor)
+			x0, y0 = anchor.Left+1, anchor.Right+1
+		}
+		if err := lcs.patienceRange(ctx, x0, x1, y0, y1, pairs); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < suffix; i++ {
+		*pairs = append(*pairs, IndexPair{Left: x1 + i, Right: y1 + i})
+	}
+	return nil
+}
+
+// patienceAnchors returns the longest increasing subsequence of the pairs of
+// the elements unique in both left[x0:x1] and right[y0:y1].
+func (lcs *lcs) patienceAnchors(x0, x1, y0, y1 int) []IndexPair {
+	if lcs.customEqual {
+		return nil
+	}
+
+	type occurrence struct {
+		count int
+		index int
+	}
+	leftCounts := map[interface{}]*occurrence{}
+	for x := x0; x < x1; x++ {
+		if key := lcs.leftKeys[x]; isBasicValue(key) {
+			if found, ok := leftCounts[key]; ok {
+				found.count++
+			} else {
+				leftCounts[key] = &occurrence{count: 1, index: x}
+			}
+		}
+	}
+	rightCounts := map[interface{}]*occurrence{}
+	for y := y0; y < y1; y++ {
+		if key := lcs.rightKeys[y]; isBasicValue(key) {
+			if found, ok := rightCounts[key]; ok {
+				found.count++
+			} else {
+				rightCounts[key] = &occurrence{count: 1, index: y}
+			}
+		}
+	}
+
+	// the unique pairs in the order of Left
+	candidates := []IndexPair{}
+	for x := x0; x < x1; x++ {
+		key := lcs.leftKeys[x]
+		if !isBasicValue(key) {
+			continue
+		}
+		left, ok := leftCounts[key]
+		if !ok || left.count != 1 {
+			continue
+		}
+		right, ok := rightCounts[key]
+		if !ok || right.count != 1 || !lcs.match(x, right.index) {
+			continue
+		}
+		candidates = append(candidates, IndexPair{Left: x, Right: right.index})
+	}
+
+	// patience sorting: piles[i] is the candidate ending the best increasing
+	// subsequence of length i+1, and previous links each to the one before
+	piles := []int{}
+	previous := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		pile := sort.Search(len(piles), func(p int) bool {
+			return candidates[piles[p]].Right > candidate.Right
+		})
+		previous[i] = -1
+		if pile > 0 {
+			previous[i] = piles[pile-1]
+		}
+		if pile == len(piles) {
+			piles = append(piles, i)
+		} else {
+			piles[pile] = i
+		}
+	}
+
+	anchors := make([]IndexPair, len(piles))
+	for i, c := len(piles)-1, -1; i >= 0; i-- {
+		if c == -1 {
+			c = piles[i]
+		} else {
+			c = previous[c]
+		}
+		anchors[i] = candidates[c]
+	}
+	return anchors
+}
+
+// rangeIndexPairsContext appends the pairs of an LCS of left[x0:x1] and
+// right[y0:y1] to pairs, backtracking a memo table of the range like
+// tableIndexPairsContext().
+func (lcs *lcs) rangeIndexPairsContext(ctx context.Context, x0, x1, y0, y1 int, pairs *[]IndexPair) error {
+	sizeX := x1 - x0 + 1
+	sizeY := y1 - y0 + 1
+	if sizeX == 1 || sizeY == 1 {
+		return nil
+	}
+
+	table := make([][]int, sizeX)
+	for x := 0; x < sizeX; x++ {
+		table[x] = make([]int, sizeY)
+	}
+	for y := 1; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			// nop
+		}
+		for x := 1; x < sizeX; x++ {
+			increment := 0
+			if lcs.match(x0+x-1, y0+y-1) {
+				increment = 1
+			}
+			table[x][y] = max(table[x-1][y-1]+increment, table[x][y-1], table[x-1][y])
+		}
+	}
+
+	start := len(*pairs)
+	*pairs = append(*pairs, make([]IndexPair, table[sizeX-1][sizeY-1])...)
+	for x, y := sizeX-1, sizeY-1; x > 0 && y > 0; {
+		if lcs.match(x0+x-1, y0+y-1) {
+			(*pairs)[start+table[x][y]-1] = IndexPair{Left: x0 + x - 1, Right: y0 + y - 1}
+			x--
+			y--
+		} else if table[x-1][y] >= table[x][y-1] {
+			x--
+		} else {
+			y--
+		}
+	}
+	return nil
+}
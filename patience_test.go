@@ -0,0 +1,64 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithPatience(t *testing.T) {
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected []IndexPair
+	}{
+		{
+			left:     []interface{}{},
+			right:    []interface{}{"a"},
+			expected: []IndexPair{},
+		},
+		{
+			left:     []interface{}{"a", "b", "c"},
+			right:    []interface{}{"a", "b", "c"},
+			expected: []IndexPair{{0, 0}, {1, 1}, {2, 2}},
+		},
+		// the unique "b" is kept over the two "x" of an LCS
+		{
+			left:     []interface{}{"x", "x", "b"},
+			right:    []interface{}{"b", "x", "x"},
+			expected: []IndexPair{{2, 0}},
+		},
+		// the ranges between the unique elements are diffed with the memo table
+		{
+			left:     []interface{}{"u", "x", "y", "x", "v", "y", "y"},
+			right:    []interface{}{"u", "y", "x", "v", "x", "y"},
+			expected: []IndexPair{{0, 0}, {2, 1}, {3, 2}, {4, 3}, {6, 5}},
+		},
+	}
+
+	for i, cs := range cases {
+		pairs := New(cs.left, cs.right, WithPatience()).IndexPairs()
+		if !reflect.DeepEqual(pairs, cs.expected) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, pairs, cs.expected)
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(30), 8)
+		right := randomInts(random, random.Intn(30), 8)
+		newLcs := New(left, right, WithPatience())
+		pairs := newLcs.IndexPairs()
+		checkCommonSubsequence(t, i, left, right, pairs)
+		if length := newLcs.Length(); length != len(pairs) {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, length, len(pairs))
+		}
+	}
+
+	// no unique elements with a custom equality
+	left, right := []interface{}{"x", "x", "b"}, []interface{}{"b", "x", "x"}
+	pairs := New(left, right, WithPatience(), WithEqual(func(a, b interface{}) bool { return a == b })).IndexPairs()
+	if expected := New(left, right).IndexPairs(); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("failed at custom equality, actual: %v, expected: %v", pairs, expected)
+	}
+}
@@ -0,0 +1,86 @@
+package golcs
+
+// PreparedLeft is an array prepared by Prepare to be compared with many others.
+type PreparedLeft struct {
+	left []interface{}
+	keys []interface{}
+	opts []Option
+	// ids identify the basic values of keys, see leftIDs()
+	ids map[interface{}]int
+	// leftIDs are the IDs of the elements of left
+	leftIDs []int
+	// index is the positions of the IDs for WithHuntSzymanski()
+	index *positionIndex
+}
+
+const (
+	// preparedOther is the ID of an element which is not a basic value
+	// and so compared with the equality.
+	preparedOther = -1
+	// preparedAbsent is the ID of a basic value of Right not in Left.
+	preparedAbsent = -2
+)
+
+// Prepare prepares an array to be compared with many others as Left.
+//
+// Every element of Left is given an integer ID from a hash of its value
+// once, and Diff() only looks up the elements of each Right, so the elements
+// are compared as integers instead of with reflect.DeepEqual. This makes the
+// one-to-many pattern, comparing one array against many others, faster than
+// calling New() for each of them. Only booleans, numbers and strings get IDs;
+// the other elements are compared as usual. The IDs are not used when an
+// option changes the equality, like WithEqual(), in which case only the
+// transforms of the options are shared. With WithHuntSzymanski(), the
+// positions of each ID in Left are indexed once as well, and every Diff()
+// looks up the matches of Right in the same index.
+//
+// A PreparedLeft, including its index, is never modified after Prepare, so
+// Diff() can be called from multiple goroutines at the same time.
+func Prepare(left []interface{}, opts ...Option) *PreparedLeft {
+	base := New(left, nil, opts...).(*lcs)
+	prepared := &PreparedLeft{left: left, keys: base.leftKeys, opts: opts}
+	if base.customEqual || base.comparisonTimeout > 0 {
+		return prepared
+	}
+
+	prepared.ids, prepared.leftIDs = leftIDs(prepared.keys)
+	if base.huntSzymanski {
+		prepared.index = newPositionIndex(prepared.ids, prepared.leftIDs)
+	}
+	return prepared
+}
+
+// Diff creates a new LCS calculator of the prepared array and right.
+func (prepared *PreparedLeft) Diff(right []interface{}) LCS {
+	lcs := New(nil, right, prepared.opts...).(*lcs)
+	lcs.left, lcs.leftKeys = prepared.left, prepared.keys
+	// the states of Left were set up for nil
+	lcs.setup()
+	if prepared.ids == nil {
+		return lcs
+	}
+
+	lcs.preparedLeft = prepared.leftIDs
+	lcs.positionIndex = prepared.index
+	lcs.preparedRight = make([]int, len(right))
+	for i, key := range lcs.rightKeys {
+		if !isBasicValue(key) {
+			lcs.preparedRight[i] = preparedOther
+		} else if id, ok := prepared.ids[key]; ok {
+			lcs.preparedRight[i] = id
+		} else {
+			lcs.preparedRight[i] = preparedAbsent
+		}
+	}
+	return lcs
+}
+
+// matchPrepared compares elements by the IDs given by a PreparedLeft.
+func (lcs *lcs) matchPrepared(x, y int) bool {
+	left, right := lcs.preparedLeft[x], lcs.preparedRight[y]
+	if left == preparedOther && right == preparedOther {
+		return lcs.equal(lcs.leftKeys[x], lcs.rightKeys[y])
+	}
+	// a basic value is never the same as another kind of value
+	return left == right
+}
@@ -0,0 +1,92 @@
+package golcs
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrepare(t *testing.T) {
+	type point struct{ x, y int }
+
+	left := []interface{}{1, "a", point{1, 2}, int64(1), math.NaN(), []int{1}, nil, "b"}
+	rights := [][]interface{}{
+		{1, "a", point{1, 2}, int64(1), math.NaN(), []int{1}, nil, "b"},
+		{int64(1), point{2, 1}, []int{1}, "b", 1},
+		{"c", 2, point{1, 2}, nil},
+		{},
+	}
+
+	prepared := Prepare(left)
+	for i, right := range rights {
+		expected := New(left, right).IndexPairs()
+		if actual := prepared.Diff(right).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+
+	// options are shared by all the diffs
+	insensitive := Prepare([]interface{}{"A", "b"}, WithNormalizers(func(value interface{}) interface{} {
+		return strings.ToLower(value.(string))
+	}))
+	if actual := insensitive.Diff([]interface{}{"a", "B"}).Length(); actual != 2 {
+		t.Errorf("failed with options, actual: %d", actual)
+	}
+}
+
+func TestPrepareConcurrently(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 50, 5)
+	rights := make([][]interface{}, 20)
+	for i := range rights {
+		rights[i] = randomInts(random, 50, 5)
+	}
+
+	prepared := Prepare(left)
+	wg := sync.WaitGroup{}
+	for _, right := range rights {
+		wg.Add(1)
+		go func(right []interface{}) {
+			defer wg.Done()
+			expected := New(left, right).IndexPairs()
+			if actual := prepared.Diff(right).IndexPairs(); !reflect.DeepEqual(actual, expected) {
+				t.Errorf("actual: %v, expected: %v", actual, expected)
+			}
+		}(right)
+	}
+	wg.Wait()
+}
+
+func benchmarkOneToMany(b *testing.B, prepare func(left []interface{}) func(right []interface{}) LCS) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 300, 20)
+	rights := make([][]interface{}, 10)
+	for i := range rights {
+		rights[i] = randomInts(random, 300, 20)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diff := prepare(left)
+		for _, right := range rights {
+			diff(right).Length()
+		}
+	}
+}
+
+func BenchmarkOneToManyNew(b *testing.B) {
+	benchmarkOneToMany(b, func(left []interface{}) func(right []interface{}) LCS {
+		return func(right []interface{}) LCS {
+			return New(left, right)
+		}
+	})
+}
+
+func BenchmarkOneToManyPrepare(b *testing.B) {
+	benchmarkOneToMany(b, func(left []interface{}) func(right []interface{}) LCS {
+		return Prepare(left).Diff
+	})
+}
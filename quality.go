@@ -0,0 +1,94 @@
+package golcs
+
+import (
+	"context"
+	"time"
+)
+
+// unlimitedBudget is the budget of WithBudget() which never runs out in practice.
+const unlimitedBudget = time.Duration(1 << 62)
+
+// DiffQuality scores how readable an edit script such as EditScript() is,
+// from 0 for a script matching nothing to 1 for one with no changes.
+//
+// A reader follows a diff hunk by hunk, so the score prefers fewer and larger
+// hunks: a hunk is a run of deletions and insertions, and each costs as much
+// as the whole rest of the script. An element matched alone between two
+// hunks, like a blank line or a brace which happens to be in both arrays,
+// costs as much as a hunk again, as it splits what reads as one change. The
+// score is the similarity of Ratio(), 2*M/T with M the matched elements and T
+// the elements of both arrays, divided by 1 + the hunks + the isolated
+// matches, so it also prefers the scripts matching more among those with the
+// same hunks. An empty script scores 1.
+func DiffQuality(edits []Edit) float64 {
+	if len(edits) == 0 {
+		return 1
+	}
+
+	equals, changes, hunks, isolated := 0, 0, 0, 0
+	for i, edit := range edits {
+		if edit.Kind != EditEqual {
+			changes++
+			if i == 0 || edits[i-1].Kind == EditEqual {
+				hunks++
+			}
+			continue
+		}
+		equals++
+		if i > 0 && i < len(edits)-1 && edits[i-1].Kind != EditEqual && edits[i+1].Kind != EditEqual {
+			isolated++
+		}
+	}
+	return float64(2*equals) / float64(2*equals+changes) / float64(1+hunks+isolated)
+}
+
+// BestReadableDiff calculates the edit scripts of the engines of this package
+// and returns the one of the highest DiffQuality().
+func BestReadableDiff(left, right []interface{}, opts ...Option) []Edit {
+	edits, _ := BestReadableDiffContext(context.Background(), left, right, opts...)
+	return edits
+}
+
+// BestReadableDiffContext is a context aware version of BestReadableDiff()
+//
+// The engines are the memo table of New(), Myers' algorithm of WithBudget()
+// without a practical budget and the patience diff of WithPatience(), tried
+// in this order with the options given, and the first of the highest score
+// wins. The memo table and Myers' algorithm both find an LCS but break the
+// ties differently, and the patience diff may give up a few matches for
+// fewer hunks. When the options already choose the engine or the pairs, such
+// as WithMaxGap(), WithMinimalDisplacement() or WithHuntSzymanski(), no
+// other engine is tried and the result is the EditScript() with them.
+func BestReadableDiffContext(ctx context.Context, left, right []interface{}, opts ...Option) ([]Edit, error) {
+	given := New(left, right, opts...).(*lcs)
+	if given.choosesPairs() {
+		return given.EditScriptContext(ctx)
+	}
+
+	engines := []Option{
+		func(*lcs) {},
+		WithBudget(unlimitedBudget),
+		WithPatience(),
+	}
+
+	var best []Edit
+	bestQuality := -1.0
+	for _, engine := range engines {
+		edits, err := New(left, right, append(append([]Option(nil), opts...), engine)...).EditScriptContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if quality := DiffQuality(edits); quality > bestQuality {
+			best, bestQuality = edits, quality
+		}
+	}
+	return best, nil
+}
+
+// choosesPairs reports whether an option sends IndexPairsContext() to an
+// engine other than the memo table of New().
+func (lcs *lcs) choosesPairs() bool {
+	return lcs.gapConstrained() || lcs.monotoneAttribute != nil || lcs.scoredMatch != nil ||
+		lcs.manyToOne || lcs.rarityWeighting || lcs.patience || lcs.budget > 0 ||
+		lcs.minimalDisplacement || lcs.matchSelector != nil || lcs.huntSzymanski || lcs.rowCheckpoints > 0
+}
@@ -0,0 +1,68 @@
+package golcs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDiffQuality(t *testing.T) {
+	equal := Edit{Kind: EditEqual}
+	del := Edit{Kind: EditDelete}
+	ins := Edit{Kind: EditInsert}
+	cases := []struct {
+		edits    []Edit
+		expected float64
+	}{
+		{[]Edit{}, 1},
+		{[]Edit{equal, equal}, 1},
+		{[]Edit{del, ins}, 0},
+		// 4/6 with a hunk
+		{[]Edit{equal, del, ins, equal}, 2.0 / 3 / 2},
+		// 4/6 with two hunks split by an isolated match
+		{[]Edit{equal, del, equal, ins}, 2.0 / 3 / 4},
+		// fewer, larger hunks score higher with the same matches
+		{[]Edit{del, del, equal, equal}, 2.0 / 3 / 2},
+		{[]Edit{del, equal, equal, del}, 2.0 / 3 / 3},
+	}
+
+	for i, cs := range cases {
+		if actual := DiffQuality(cs.edits); math.Abs(actual-cs.expected) > 1e-9 {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, cs.expected)
+		}
+	}
+}
+
+func TestBestReadableDiff(t *testing.T) {
+	functions := func(names ...string) []interface{} {
+		lines := []interface{}{}
+		for _, name := range names {
+			lines = append(lines, "func "+name+"() {", "return "+name, "}", "")
+		}
+		return lines
+	}
+	// the memo table matches the braces and blank lines of a and e
+	left := functions("a", "b")
+	right := functions("a", "e", "f")
+
+	patience := New(left, right, WithPatience()).EditScript()
+	if table := New(left, right).EditScript(); DiffQuality(patience) <= DiffQuality(table) {
+		t.Errorf("failed at quality, patience: %v, table: %v", DiffQuality(patience), DiffQuality(table))
+	}
+	if edits := BestReadableDiff(left, right); !reflect.DeepEqual(edits, patience) {
+		t.Errorf("failed at best, actual: %v, expected: %v", edits, patience)
+	}
+
+	// an LCS found by the memo table wins the ties
+	left, right = []interface{}{1, 2, 3}, []interface{}{1, 3}
+	if edits, expected := BestReadableDiff(left, right), New(left, right).EditScript(); !reflect.DeepEqual(edits, expected) {
+		t.Errorf("failed at ties, actual: %v, expected: %v", edits, expected)
+	}
+
+	// an option choosing the pairs is not overridden by the engines
+	left, right = functions("a", "b"), functions("a", "e", "f")
+	expected := New(left, right, WithMinimalDisplacement()).EditScript()
+	if edits := BestReadableDiff(left, right, WithMinimalDisplacement()); !reflect.DeepEqual(edits, expected) {
+		t.Errorf("failed at minimal displacement, actual: %v, expected: %v", edits, expected)
+	}
+}
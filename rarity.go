@@ -0,0 +1,97 @@
+package golcs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WithRarityWeighting finds the common subsequence of the highest total
+// weight instead of the longest one, where a match weighs more the rarer its
+// element is, like the inverse document frequency of TF-IDF.
+//
+// The weight of a match of an element is 2/f, where f is the number of the
+// elements with the same key in Left and Right together; booleans, numbers
+// and strings have their values as keys, with one key for all the NaNs of a
+// type, and the other elements their texts formatted with %#v. The weight is
+// normalized to 1 for an element which appears once in each array, and goes
+// down to 0 for common ones, so matching a unique line is worth more than
+// matching many blank lines. IndexPairs() and the methods on it follow the
+// weights and may have fewer pairs than the LCS; Length() is their number,
+// and WeightedScore() is their total weight. Table() and the methods on it
+// still use the longest subsequence.
+func WithRarityWeighting() Option {
+	return func(lcs *lcs) {
+		lcs.rarityWeighting = true
+	}
+}
+
+// WeightedScore implements LCS.WeightedScore()
+func (lcs *lcs) WeightedScore() float64 {
+	score, _ := lcs.WeightedScoreContext(context.Background())
+	return score
+}
+
+// WeightedScoreContext implements LCS.WeightedScoreContext()
+//
+// The score is the total weight of IndexPairs() as defined by
+// WithRarityWeighting(), also without the option.
+func (lcs *lcs) WeightedScoreContext(ctx context.Context) (float64, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	weights := lcs.rarityWeights()
+	score := 0.0
+	for _, pair := range pairs {
+		score += weights[pair.Left]
+	}
+	return score, nil
+}
+
+// rarityWeights calculates the weight of a match of each element of Left.
+func (lcs *lcs) rarityWeights() []float64 {
+	counts := map[interface{}]int{}
+	for _, keys := range [][]interface{}{lcs.leftKeys, lcs.rightKeys} {
+		for _, key := range keys {
+			counts[rarityKey(key)]++
+		}
+	}
+	weights := make([]float64, len(lcs.leftKeys))
+	for x, key := range lcs.leftKeys {
+		weights[x] = 2 / float64(counts[rarityKey(key)])
+	}
+	return weights
+}
+
+// rarityKey returns the key counting the elements the same as key. A NaN is
+// never equal to itself as a key of a map, so all the NaNs of a type share
+// a nanKey instead, like WithBloomPrefilter() makes the zeros share one.
+func rarityKey(key interface{}) interface{} {
+	if isBasicValue(key) {
+		if key != key {
+			return nanKey{reflect.TypeOf(key)}
+		}
+		return key
+	}
+	return fmt.Sprintf("%#v", key)
+}
+
+// nanKey is the rarityKey of the NaNs of a type, including the complex
+// numbers with a NaN part.
+type nanKey struct {
+	reflect.Type
+}
+
+// rarityIndexPairsContext finds the pairs of the highest total weight.
+func (lcs *lcs) rarityIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	weights := lcs.rarityWeights()
+	pairs, _, err := maxWeightIndexPairsContext(ctx, len(lcs.left), len(lcs.right), func(x, y int) float64 {
+		if !lcs.match(x, y) {
+			return 0
+		}
+		return weights[x]
+	})
+	return pairs, err
+}
@@ -0,0 +1,99 @@
+package golcs
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithRarityWeighting(t *testing.T) {
+	cases := []struct {
+		left   []interface{}
+		right  []interface{}
+		pairs  []IndexPair
+		plain  []IndexPair
+		score  float64
+		lcsLen int
+	}{
+		{
+			// x appears 6 times and weighs 1/3 while a and b weigh 1
+			left:   []interface{}{"a", "x", "x", "b"},
+			right:  []interface{}{"x", "x", "a", "b", "x", "x"},
+			pairs:  []IndexPair{{Left: 0, Right: 2}, {Left: 3, Right: 3}},
+			plain:  []IndexPair{{Left: 0, Right: 2}, {Left: 1, Right: 4}, {Left: 2, Right: 5}},
+			score:  2,
+			lcsLen: 3,
+		},
+		{
+			left:   []interface{}{1, 2, 3},
+			right:  []interface{}{1, 2, 3},
+			pairs:  []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}, {Left: 2, Right: 2}},
+			plain:  []IndexPair{{Left: 0, Right: 0}, {Left: 1, Right: 1}, {Left: 2, Right: 2}},
+			score:  3,
+			lcsLen: 3,
+		},
+		{
+			left:   []interface{}{1, 2},
+			right:  []interface{}{3},
+			pairs:  []IndexPair{},
+			plain:  []IndexPair{},
+			score:  0,
+			lcsLen: 0,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right, WithRarityWeighting())
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+		if actual := newLcs.Length(); actual != len(c.pairs) {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, len(c.pairs))
+		}
+		if actual := newLcs.WeightedScore(); math.Abs(actual-c.score) > 1e-9 {
+			t.Errorf("test case %d failed at score, actual: %v, expected: %v", i, actual, c.score)
+		}
+		plain := New(c.left, c.right)
+		if actual := plain.IndexPairs(); !reflect.DeepEqual(actual, c.plain) {
+			t.Errorf("test case %d failed at plain pairs, actual: %v, expected: %v", i, actual, c.plain)
+		}
+		if actual := plain.Length(); actual != c.lcsLen {
+			t.Errorf("test case %d failed at plain length, actual: %v, expected: %v", i, actual, c.lcsLen)
+		}
+	}
+}
+
+func TestWithRarityWeightingRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(30), 5)
+		right := randomInts(random, random.Intn(30), 5)
+
+		weighted := New(left, right, WithRarityWeighting())
+		pairs := weighted.IndexPairs()
+		checkCommonSubsequence(t, i, left, right, pairs)
+		// the weighted pairs score at least as high as the LCS
+		if actual, lcs := weighted.WeightedScore(), New(left, right).WeightedScore(); actual < lcs-1e-9 {
+			t.Errorf("test case %d failed at score, actual: %v, lcs: %v", i, actual, lcs)
+		}
+	}
+}
+
+func TestWithRarityWeightingNaN(t *testing.T) {
+	// the four NaNs share a key and weigh 1/2 each, less than a
+	nan := math.NaN()
+	left := []interface{}{"a", nan}
+	right := []interface{}{nan, nan, nan, "a"}
+
+	newLcs := New(left, right, WithJSONEquality(), WithRarityWeighting())
+	if actual, expected := newLcs.IndexPairs(), []IndexPair{{Left: 0, Right: 3}}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at pairs, actual: %v, expected: %v", actual, expected)
+	}
+	if actual := newLcs.WeightedScore(); actual != 1 {
+		t.Errorf("failed at score, actual: %v, expected: %v", actual, 1)
+	}
+	if actual, expected := rarityKey(float32(nan)), rarityKey(nan); actual == expected {
+		t.Errorf("failed at types, the NaNs of float32 and float64 share %v", actual)
+	}
+}
@@ -0,0 +1,181 @@
+package golcs
+
+import (
+	"context"
+	"reflect"
+)
+
+// LeftCoverage implements LCS.LeftCoverage()
+func (lcs *lcs) LeftCoverage() float64 {
+	coverage, _ := lcs.LeftCoverageContext(context.Background())
+	return coverage
+}
+
+// LeftCoverageContext implements LCS.LeftCoverageContext()
+//
+// The coverage is Length() / len(Left), that is, how much of Left survives in
+// Right. It is 1.0 when both arrays are empty and 0.0 when only Left is empty.
+func (lcs *lcs) LeftCoverageContext(ctx context.Context) (float64, error) {
+	return lcs.coverageContext(ctx, len(lcs.left))
+}
+
+// RightCoverage implements LCS.RightCoverage()
+func (lcs *lcs) RightCoverage() float64 {
+	coverage, _ := lcs.RightCoverageContext(context.Background())
+	return coverage
+}
+
+// RightCoverageContext implements LCS.RightCoverageContext()
+//
+// The coverage is Length() / len(Right), that is, how much of Right was
+// already in Left. It is 1.0 when both arrays are empty and 0.0 when only
+// Right is empty.
+func (lcs *lcs) RightCoverageContext(ctx context.Context) (float64, error) {
+	return lcs.coverageContext(ctx, len(lcs.right))
+}
+
+// OverlapRatio implements LCS.OverlapRatio()
+func (lcs *lcs) OverlapRatio() float64 {
+	ratio, _ := lcs.OverlapRatioContext(context.Background())
+	return ratio
+}
+
+// OverlapRatioContext implements LCS.OverlapRatioContext()
+//
+// The ratio is Length() / max(len(Left), len(Right)), the fraction of the
+// larger array which is in common, so it is 1.0 only when the arrays are the
+// same, and it tells how much of one array the other contains. It is the
+// lesser of LeftCoverage() and RightCoverage(), and it is never more than
+// Ratio(), the harmonic mean of the two, which it equals for arrays of the
+// same size. It is 1.0 when both arrays are empty.
+func (lcs *lcs) OverlapRatioContext(ctx context.Context) (float64, error) {
+	return lcs.coverageContext(ctx, max(len(lcs.left), len(lcs.right)))
+}
+
+func (lcs *lcs) coverageContext(ctx context.Context, size int) (float64, error) {
+	length, err := lcs.LengthContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 {
+		if len(lcs.left) == 0 && len(lcs.right) == 0 {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return float64(length) / float64(size), nil
+}
+
+// Ratio implements LCS.Ratio()
+func (lcs *lcs) Ratio() float64 {
+	ratio, _ := lcs.RatioContext(context.Background())
+	return ratio
+}
+
+// RatioContext implements LCS.RatioContext()
+//
+// The ratio is the same as that of Python's difflib: 1.0 for the same arrays
+// including two empty ones, and 0.0 when nothing is in common.
+func (lcs *lcs) RatioContext(ctx context.Context) (float64, error) {
+	length, err := lcs.LengthContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ratio(length, len(lcs.left), len(lcs.right)), nil
+}
+
+// QuickRatio implements LCS.QuickRatio()
+//
+// The bound is calculated in O(m+n) time from the number of elements in
+// common regardless of their order, so it is never less than Ratio(). Only
+// booleans, numbers and strings are counted by their values; any other
+// element is assumed to match any other non-basic element of the other array,
+// and with WithEqual() every element is assumed to match any element.
+func (lcs *lcs) QuickRatio() float64 {
+	if lcs.customEqual {
+		return ratio(min(len(lcs.left), len(lcs.right)), len(lcs.left), len(lcs.right))
+	}
+
+	counts := map[interface{}]int{}
+	othersLeft := 0
+	for _, key := range lcs.leftKeys {
+		if isBasicValue(key) {
+			counts[key]++
+		} else {
+			othersLeft++
+		}
+	}
+	matches, othersRight := 0, 0
+	for _, key := range lcs.rightKeys {
+		if !isBasicValue(key) {
+			othersRight++
+		} else if counts[key] > 0 {
+			counts[key]--
+			matches++
+		}
+	}
+	// other elements are never the same as basic values
+	matches += min(othersLeft, othersRight)
+	return ratio(matches, len(lcs.left), len(lcs.right))
+}
+
+// isBasicValue reports whether a value is a boolean, a number or a string,
+// for which reflect.DeepEqual is the same as ==.
+func isBasicValue(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// HunkRatios implements LCS.HunkRatios()
+func (lcs *lcs) HunkRatios(contextSize int) []float64 {
+	ratios, _ := lcs.HunkRatiosContext(context.Background(), contextSize)
+	return ratios
+}
+
+// HunkRatiosContext implements LCS.HunkRatiosContext()
+//
+// The ratio of a hunk is 2*M/T, where M is the LCS length of the ranges of
+// Left and Right in the hunk, including its context, and T is the total size
+// of the ranges. A ratio close to 1.0 is a small tweak and one close to 0.0 is
+// a rewrite. The LCS of each hunk is calculated again with a single row of
+// the memo table over the elements of the hunk as they are compared by the
+// calculator, so the excluded elements and the other options of the equality
+// apply at their original indices. It adds O(a*b) time for a hunk with a
+// elements of Left and b of Right.
+func (lcs *lcs) HunkRatiosContext(ctx context.Context, contextSize int) ([]float64, error) {
+	hunks, err := lcs.HunksContext(ctx, contextSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ratios := make([]float64, len(hunks))
+	for i, hunk := range hunks {
+		x0, y0 := hunk.LeftStart, hunk.RightStart
+		length, err := lengthContext(ctx, hunk.LeftLength, hunk.RightLength, func(x, y int) bool {
+			return lcs.match(x0+x, y0+y)
+		})
+		if err == nil {
+			err = lcs.failed()
+		}
+		if err != nil {
+			return nil, err
+		}
+		ratios[i] = ratio(length, hunk.LeftLength, hunk.RightLength)
+	}
+	return ratios, nil
+}
+
+// ratio calculates 2*M/T for the LCS length M and the total size T of two
+// arrays. It is 1.0 when both arrays are empty.
+func ratio(length, sizeLeft, sizeRight int) float64 {
+	if sizeLeft+sizeRight == 0 {
+		return 1
+	}
+	return 2 * float64(length) / float64(sizeLeft+sizeRight)
+}
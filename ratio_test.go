@@ -0,0 +1,156 @@
+package golcs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	cases := []struct {
+		left          []interface{}
+		right         []interface{}
+		leftCoverage  float64
+		rightCoverage float64
+		overlap       float64
+	}{
+		{
+			left:          []interface{}{1, 2, 3, 4},
+			right:         []interface{}{2, 4},
+			leftCoverage:  0.5,
+			rightCoverage: 1,
+			overlap:       0.5,
+		},
+		{
+			left:          []interface{}{2, 4},
+			right:         []interface{}{1, 2, 3, 4},
+			leftCoverage:  1,
+			rightCoverage: 0.5,
+			overlap:       0.5,
+		},
+		{
+			left:          []interface{}{1, 2},
+			right:         []interface{}{3, 4, 5},
+			leftCoverage:  0,
+			rightCoverage: 0,
+			overlap:       0,
+		},
+		{
+			left:          []interface{}{},
+			right:         []interface{}{},
+			leftCoverage:  1,
+			rightCoverage: 1,
+			overlap:       1,
+		},
+		{
+			left:          []interface{}{},
+			right:         []interface{}{1},
+			leftCoverage:  0,
+			rightCoverage: 0,
+			overlap:       0,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right)
+		// Length() must not affect which side is which
+		newLcs.Length()
+
+		actualLeft := newLcs.LeftCoverage()
+		if actualLeft != c.leftCoverage {
+			t.Errorf("test case %d failed at left coverage, actual: %f, expected: %f", i, actualLeft, c.leftCoverage)
+		}
+
+		actualRight := newLcs.RightCoverage()
+		if actualRight != c.rightCoverage {
+			t.Errorf("test case %d failed at right coverage, actual: %f, expected: %f", i, actualRight, c.rightCoverage)
+		}
+
+		actualOverlap := newLcs.OverlapRatio()
+		if actualOverlap != c.overlap {
+			t.Errorf("test case %d failed at overlap ratio, actual: %f, expected: %f", i, actualOverlap, c.overlap)
+		}
+	}
+}
+
+func TestHunkRatios(t *testing.T) {
+	left := []interface{}{
+		"a", "b", "c", "d",
+		"k1", "k2", "k3", "k4", "k5", "k6",
+		"e", "f", "g", "h",
+	}
+	right := []interface{}{
+		"a", "b", "x", "d",
+		"k1", "k2", "k3", "k4", "k5", "k6",
+		"w", "x", "y", "z",
+	}
+	newLcs := New(left, right)
+
+	// hunks of [b c d] and [k6 e f g h] in left with 1 element of context
+	expected := []float64{2 * 2.0 / 6, 2 * 1.0 / 10}
+	actual := newLcs.HunkRatios(1)
+	if len(actual) != len(expected) {
+		t.Fatalf("unexpected number of hunks: %v", actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("failed at hunk %d, actual: %f, expected: %f", i, actual[i], expected[i])
+		}
+	}
+
+	if ratios := New(left, left).HunkRatios(1); len(ratios) != 0 {
+		t.Errorf("unexpected ratios for the same arrays: %v", ratios)
+	}
+
+	// the excluded indices are those of the whole arrays
+	excluded, err := New(left, right, WithExcludedLeft([]int{12})).HunkRatiosContext(context.Background(), 1)
+	if err != nil || !reflect.DeepEqual(excluded, expected) {
+		t.Errorf("failed at exclusion, actual: %v, expected: %v, error: %v", excluded, expected, err)
+	}
+}
+
+func TestRatio(t *testing.T) {
+	type point struct{ x, y int }
+
+	cases := []struct {
+		left       []interface{}
+		right      []interface{}
+		ratio      float64
+		quickRatio float64
+	}{
+		{
+			left:       []interface{}{1, 2, 3, 4},
+			right:      []interface{}{4, 3, 2, 1},
+			ratio:      2 * 1.0 / 8,
+			quickRatio: 1,
+		},
+		{
+			left:       []interface{}{1, 2, 3},
+			right:      []interface{}{1, 2, 3},
+			ratio:      1,
+			quickRatio: 1,
+		},
+		{
+			left:       []interface{}{},
+			right:      []interface{}{},
+			ratio:      1,
+			quickRatio: 1,
+		},
+		{
+			left:       []interface{}{1, "1", point{1, 2}},
+			right:      []interface{}{int64(1), "1", point{2, 1}, point{1, 2}},
+			ratio:      2 * 2.0 / 7,
+			quickRatio: 2 * 2.0 / 7,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right)
+		if actual := newLcs.Ratio(); actual != c.ratio {
+			t.Errorf("test case %d failed at ratio, actual: %f, expected: %f", i, actual, c.ratio)
+		}
+		if actual := newLcs.QuickRatio(); actual != c.quickRatio {
+			t.Errorf("test case %d failed at quick ratio, actual: %f, expected: %f", i, actual, c.quickRatio)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package golcs
+
+import (
+	"context"
+	"reflect"
+)
+
+// DiffByRecords implements LCS.DiffByRecords()
+func (lcs *lcs) DiffByRecords(separator interface{}) [][]Edit {
+	scripts, _ := lcs.DiffByRecordsContext(context.Background(), separator)
+	return scripts
+}
+
+// DiffByRecordsContext implements LCS.DiffByRecordsContext()
+//
+// Left and Right are split into records at the elements equal to separator
+// with reflect.DeepEqual, which are in no record, so n separators make n+1
+// records, some of which may be empty; an empty array has no records. The
+// identity of a record is its first element, such as the ID of a row, and
+// the records are aligned along the LCS of their identities compared with
+// the equality, where two empty records are the same and an empty one is
+// like no other. Each aligned pair of records is diffed with the memo table,
+// and a record not aligned, one removed from Left or added to Right, is all
+// deleted or all inserted. The result has an edit script for each pair or
+// record not aligned in order, with the deleted records before the inserted
+// ones between two pairs. Left and Right of the edits are the indices in the
+// whole arrays, so the scripts with the separators between them make an edit
+// script of the arrays.
+func (lcs *lcs) DiffByRecordsContext(ctx context.Context, separator interface{}) ([][]Edit, error) {
+	if err := lcs.compareAll(ctx); err != nil {
+		return nil, err
+	}
+	leftRecords := splitRecords(lcs.left, separator)
+	rightRecords := splitRecords(lcs.right, separator)
+
+	pairs, _, err := maxWeightIndexPairsContext(ctx, len(leftRecords), len(rightRecords), func(i, j int) float64 {
+		if lcs.sameIdentities(leftRecords[i], rightRecords[j]) {
+			return 1
+		}
+		return 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := [][]Edit{}
+	i, j := 0, 0
+	for k := 0; k <= len(pairs); k++ {
+		pair := IndexPair{Left: len(leftRecords), Right: len(rightRecords)}
+		if k < len(pairs) {
+			pair = pairs[k]
+		}
+		for ; i < pair.Left; i++ {
+			script, err := lcs.recordEditScript(ctx, leftRecords[i], [2]int{-1, -1})
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, script)
+		}
+		for ; j < pair.Right; j++ {
+			script, err := lcs.recordEditScript(ctx, [2]int{-1, -1}, rightRecords[j])
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, script)
+		}
+		if k < len(pairs) {
+			script, err := lcs.recordEditScript(ctx, leftRecords[i], rightRecords[j])
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, script)
+			i, j = i+1, j+1
+		}
+	}
+
+	if err := lcs.failed(); err != nil {
+		return nil, err
+	}
+	return scripts, nil
+}
+
+// splitRecords returns the ranges [start, end) of the records of values.
+func splitRecords(values []interface{}, separator interface{}) [][2]int {
+	records := [][2]int{}
+	if len(values) == 0 {
+		return records
+	}
+	start := 0
+	for i, value := range values {
+		if reflect.DeepEqual(value, separator) {
+			records = append(records, [2]int{start, i})
+			start = i + 1
+		}
+	}
+	return append(records, [2]int{start, len(values)})
+}
+
+// sameIdentities reports whether the records of Left and Right have the same identity.
+func (lcs *lcs) sameIdentities(left, right [2]int) bool {
+	leftEmpty, rightEmpty := left[0] == left[1], right[0] == right[1]
+	if leftEmpty || rightEmpty {
+		return leftEmpty && rightEmpty
+	}
+	return lcs.match(left[0], right[0])
+}
+
+// recordEditScript diffs a record of Left and one of Right. A record of
+// {-1, -1} is absent, which deletes or inserts the other.
+func (lcs *lcs) recordEditScript(ctx context.Context, left, right [2]int) ([]Edit, error) {
+	if left[0] == -1 {
+		left = [2]int{0, 0}
+	}
+	if right[0] == -1 {
+		right = [2]int{0, 0}
+	}
+	pairs := []IndexPair{}
+	if err := lcs.rangeIndexPairsContext(ctx, left[0], left[1], right[0], right[1], &pairs); err != nil {
+		return nil, err
+	}
+
+	edits := []Edit{}
+	x, y := left[0], right[0]
+	for i := 0; i <= len(pairs); i++ {
+		pair := IndexPair{Left: left[1], Right: right[1]}
+		if i < len(pairs) {
+			pair = pairs[i]
+		}
+		for ; x < pair.Left; x++ {
+			edits = append(edits, Edit{Kind: EditDelete, Left: x, Right: -1, Value: lcs.left[x]})
+		}
+		for ; y < pair.Right; y++ {
+			edits = append(edits, Edit{Kind: EditInsert, Left: -1, Right: y, Value: lcs.right[y]})
+		}
+		if i < len(pairs) {
+			edits = append(edits, Edit{Kind: EditEqual, Left: x, Right: y, Value: lcs.left[x]})
+			x++
+			y++
+		}
+	}
+	return edits, nil
+}
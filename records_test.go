@@ -0,0 +1,102 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffByRecords(t *testing.T) {
+	left := []interface{}{"a", "1", "|", "b", "2", "|", "c", "3"}
+	// b is removed, c is changed and d is added
+	right := []interface{}{"a", "1", "|", "c", "4", "|", "d", "5"}
+
+	expected := [][]Edit{
+		{
+			{Kind: EditEqual, Left: 0, Right: 0, Value: "a"},
+			{Kind: EditEqual, Left: 1, Right: 1, Value: "1"},
+		},
+		{
+			{Kind: EditDelete, Left: 3, Right: -1, Value: "b"},
+			{Kind: EditDelete, Left: 4, Right: -1, Value: "2"},
+		},
+		{
+			{Kind: EditEqual, Left: 6, Right: 3, Value: "c"},
+			{Kind: EditDelete, Left: 7, Right: -1, Value: "3"},
+			{Kind: EditInsert, Left: -1, Right: 4, Value: "4"},
+		},
+		{
+			{Kind: EditInsert, Left: -1, Right: 6, Value: "d"},
+			{Kind: EditInsert, Left: -1, Right: 7, Value: "5"},
+		},
+	}
+	if actual := New(left, right).DiffByRecords("|"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed at changed records, actual: %v, expected: %v", actual, expected)
+	}
+
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected [][]Edit
+	}{
+		{
+			left:     []interface{}{},
+			right:    []interface{}{},
+			expected: [][]Edit{},
+		},
+		// an added record between two aligned ones
+		{
+			left:  []interface{}{"a", "|", "c"},
+			right: []interface{}{"a", "|", "b", "|", "c"},
+			expected: [][]Edit{
+				{{Kind: EditEqual, Left: 0, Right: 0, Value: "a"}},
+				{{Kind: EditInsert, Left: -1, Right: 2, Value: "b"}},
+				{{Kind: EditEqual, Left: 2, Right: 4, Value: "c"}},
+			},
+		},
+		// a removed record and a changed one
+		{
+			left:  []interface{}{"a", "|", "b", "|", "c", "x"},
+			right: []interface{}{"a", "|", "c", "y"},
+			expected: [][]Edit{
+				{{Kind: EditEqual, Left: 0, Right: 0, Value: "a"}},
+				{{Kind: EditDelete, Left: 2, Right: -1, Value: "b"}},
+				{
+					{Kind: EditEqual, Left: 4, Right: 2, Value: "c"},
+					{Kind: EditDelete, Left: 5, Right: -1, Value: "x"},
+					{Kind: EditInsert, Left: -1, Right: 3, Value: "y"},
+				},
+			},
+		},
+		// an empty record at the end
+		{
+			left:  []interface{}{"a", "|"},
+			right: []interface{}{"a", "|"},
+			expected: [][]Edit{
+				{{Kind: EditEqual, Left: 0, Right: 0, Value: "a"}},
+				{},
+			},
+		},
+		// a record of a different identity is removed and added
+		{
+			left:  []interface{}{"a", "1"},
+			right: []interface{}{"b", "1"},
+			expected: [][]Edit{
+				{
+					{Kind: EditDelete, Left: 0, Right: -1, Value: "a"},
+					{Kind: EditDelete, Left: 1, Right: -1, Value: "1"},
+				},
+				{
+					{Kind: EditInsert, Left: -1, Right: 0, Value: "b"},
+					{Kind: EditInsert, Left: -1, Right: 1, Value: "1"},
+				},
+			},
+		},
+	}
+
+	for i, cs := range cases {
+		actual := New(cs.left, cs.right).DiffByRecords("|")
+		if !reflect.DeepEqual(actual, cs.expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, cs.expected)
+		}
+	}
+}
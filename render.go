@@ -0,0 +1,79 @@
+package golcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RenderLineKind represents the kind of a RenderLine.
+type RenderLineKind int
+
+const (
+	// RenderLineContext is an unchanged line around the changes.
+	RenderLineContext RenderLineKind = iota
+	// RenderLineAdd is a line only in Right.
+	RenderLineAdd
+	// RenderLineDelete is a line only in Left.
+	RenderLineDelete
+)
+
+// RenderLine is a line in the body of a RenderHunk.
+type RenderLine struct {
+	Kind RenderLineKind
+	// LeftNumber is the line number in Left counted from 1, or 0 for RenderLineAdd.
+	LeftNumber int
+	// RightNumber is the line number in Right counted from 1, or 0 for RenderLineDelete.
+	RightNumber int
+	// Text is the element written with fmt.Sprint without its "\n" terminator.
+	Text string
+}
+
+// RenderHunk is a hunk of a unified diff ready to be displayed by a code review UI.
+type RenderHunk struct {
+	// Header is the hunk header such as "@@ -1,3 +1,4 @@".
+	Header string
+	Lines  []RenderLine
+}
+
+// RenderHunks implements LCS.RenderHunks()
+func (lcs *lcs) RenderHunks(contextSize int) []RenderHunk {
+	hunks, _ := lcs.RenderHunksContext(context.Background(), contextSize)
+	return hunks
+}
+
+// RenderHunksContext implements LCS.RenderHunksContext()
+//
+// The hunks are those of Hunks() with contextSize lines of context, as shown
+// by GitHub and git diff with 3. The header has the range of each side as
+// "start,length" where start counts lines from 1; the length is omitted when
+// it is 1, and an empty range has the number of the line before it, 0 at the
+// beginning, as PatchFile() writes. Each line has its numbers in Left and
+// Right, which go up by one for every line of the side in the hunk.
+func (lcs *lcs) RenderHunksContext(ctx context.Context, contextSize int) ([]RenderHunk, error) {
+	hunks, err := lcs.HunksContext(ctx, contextSize)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]RenderHunk, len(hunks))
+	for i, hunk := range hunks {
+		rendered[i].Header = fmt.Sprintf("@@ -%s +%s @@",
+			unifiedRange(hunk.LeftStart, hunk.LeftLength),
+			unifiedRange(hunk.RightStart, hunk.RightLength))
+		rendered[i].Lines = make([]RenderLine, len(hunk.Edits))
+		for j, edit := range hunk.Edits {
+			line := RenderLine{Text: strings.TrimSuffix(fmt.Sprint(edit.Value), "\n")}
+			switch edit.Kind {
+			case EditEqual:
+				line.Kind, line.LeftNumber, line.RightNumber = RenderLineContext, edit.Left+1, edit.Right+1
+			case EditDelete:
+				line.Kind, line.LeftNumber = RenderLineDelete, edit.Left+1
+			case EditInsert:
+				line.Kind, line.RightNumber = RenderLineAdd, edit.Right+1
+			}
+			rendered[i].Lines[j] = line
+		}
+	}
+	return rendered, nil
+}
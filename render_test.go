@@ -0,0 +1,59 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderHunks(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	after := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n14\n15\n16\n"
+
+	// the same as diff -U1
+	expected := []RenderHunk{
+		{
+			Header: "@@ -2,3 +2,3 @@",
+			Lines: []RenderLine{
+				{Kind: RenderLineContext, LeftNumber: 2, RightNumber: 2, Text: "2"},
+				{Kind: RenderLineDelete, LeftNumber: 3, Text: "3"},
+				{Kind: RenderLineAdd, RightNumber: 3, Text: "three"},
+				{Kind: RenderLineContext, LeftNumber: 4, RightNumber: 4, Text: "4"},
+			},
+		},
+		{
+			Header: "@@ -12,4 +12,4 @@",
+			Lines: []RenderLine{
+				{Kind: RenderLineContext, LeftNumber: 12, RightNumber: 12, Text: "12"},
+				{Kind: RenderLineDelete, LeftNumber: 13, Text: "13"},
+				{Kind: RenderLineContext, LeftNumber: 14, RightNumber: 13, Text: "14"},
+				{Kind: RenderLineContext, LeftNumber: 15, RightNumber: 14, Text: "15"},
+				{Kind: RenderLineAdd, RightNumber: 15, Text: "16"},
+			},
+		},
+	}
+	if actual := NewLines(before, after).RenderHunks(1); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %#v, expected: %#v", actual, expected)
+	}
+
+	// the same as diff -u
+	headers := []string{"@@ -1,6 +1,6 @@", "@@ -10,6 +10,6 @@"}
+	for i, hunk := range NewLines(before, after).RenderHunks(3) {
+		if hunk.Header != headers[i] {
+			t.Errorf("hunk %d failed at header, actual: %v, expected: %v", i, hunk.Header, headers[i])
+		}
+	}
+
+	cases := []struct {
+		old    string
+		new    string
+		header string
+	}{
+		{old: "", new: "a\n", header: "@@ -0,0 +1 @@"},
+		{old: "a\nb\n", new: "a\n", header: "@@ -1,2 +1 @@"},
+	}
+	for i, c := range cases {
+		if actual := NewLines(c.old, c.new).RenderHunks(3)[0].Header; actual != c.header {
+			t.Errorf("test case %d failed at header, actual: %v, expected: %v", i, actual, c.header)
+		}
+	}
+}
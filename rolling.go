@@ -0,0 +1,89 @@
+package golcs
+
+import "context"
+
+// RollingLCS calculates the LCS length of the recent elements of two unbounded
+// streams created by NewRolling.
+type RollingLCS struct {
+	opts  []Option
+	left  ring
+	right ring
+}
+
+// ring is a ring buffer keeping the last elements pushed.
+type ring struct {
+	values []interface{}
+	// start is the index of the oldest element when the buffer is full
+	start int
+	full  bool
+}
+
+// NewRolling creates a new calculator of the LCS length over the last window
+// elements of two streams. The elements are pushed to either side one by one
+// with PushLeft() and PushRight(), and Length() is the LCS length of the
+// current windows: the last window elements of each side, or all of them
+// before window elements are pushed. An element pushed out of a window is
+// forgotten along with its matches, so the length is of the recent elements
+// only, an approximate rolling similarity with memory bounded by window
+// whatever the lengths of the streams. The options are those of New(), and
+// window less than 1 is treated as 1. A RollingLCS is not safe for
+// concurrent use.
+func NewRolling(window int, opts ...Option) *RollingLCS {
+	window = max(window, 1)
+	return &RollingLCS{
+		opts:  opts,
+		left:  ring{values: make([]interface{}, 0, window)},
+		right: ring{values: make([]interface{}, 0, window)},
+	}
+}
+
+// PushLeft adds an element to the end of the left stream.
+func (rolling *RollingLCS) PushLeft(value interface{}) {
+	rolling.left.push(value)
+}
+
+// PushRight adds an element to the end of the right stream.
+func (rolling *RollingLCS) PushRight(value interface{}) {
+	rolling.right.push(value)
+}
+
+// Left returns the current window of the left stream, from the oldest element.
+func (rolling *RollingLCS) Left() []interface{} {
+	return rolling.left.window()
+}
+
+// Right returns the current window of the right stream, from the oldest element.
+func (rolling *RollingLCS) Right() []interface{} {
+	return rolling.right.window()
+}
+
+// Length calculates the LCS length of the current windows.
+func (rolling *RollingLCS) Length() int {
+	length, _ := rolling.LengthContext(context.Background())
+	return length
+}
+
+// LengthContext is a context aware version of Length()
+//
+// The length is calculated over the windows from scratch, in O(window^2) time
+// with O(window) memory like LCS.Length(), since the matches of the elements
+// pushed out change the whole memo table.
+func (rolling *RollingLCS) LengthContext(ctx context.Context) (int, error) {
+	return New(rolling.Left(), rolling.Right(), rolling.opts...).LengthContext(ctx)
+}
+
+func (r *ring) push(value interface{}) {
+	if !r.full {
+		r.values = append(r.values, value)
+		r.full = len(r.values) == cap(r.values)
+		return
+	}
+	r.values[r.start] = value
+	r.start = (r.start + 1) % len(r.values)
+}
+
+func (r *ring) window() []interface{} {
+	values := make([]interface{}, 0, len(r.values))
+	values = append(values, r.values[r.start:]...)
+	return append(values, r.values[:r.start]...)
+}
@@ -0,0 +1,47 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRollingLCS(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i, window := range []int{0, 1, 3, 10} {
+		rolling := NewRolling(window)
+		left, right := []interface{}{}, []interface{}{}
+		size := max(window, 1)
+		for n := 0; n < 100; n++ {
+			value := random.Intn(4)
+			if random.Intn(2) == 0 {
+				rolling.PushLeft(value)
+				left = append(left, value)
+			} else {
+				rolling.PushRight(value)
+				right = append(right, value)
+			}
+
+			leftWindow := left[len(left)-min(len(left), size):]
+			rightWindow := right[len(right)-min(len(right), size):]
+			if !reflect.DeepEqual(rolling.Left(), leftWindow) || !reflect.DeepEqual(rolling.Right(), rightWindow) {
+				t.Errorf("test case %d failed at windows of %d, actual: %v, %v, expected: %v, %v", i, n, rolling.Left(), rolling.Right(), leftWindow, rightWindow)
+			}
+			if actual, expected := rolling.Length(), New(leftWindow, rightWindow).Length(); actual != expected {
+				t.Errorf("test case %d failed at length of %d, actual: %d, expected: %d", i, n, actual, expected)
+			}
+		}
+	}
+
+	// the matches pushed out are forgotten
+	rolling := NewRolling(2, WithEqual(func(a, b interface{}) bool { return a.(string)[0] == b.(string)[0] }))
+	for _, value := range []string{"a1", "b1", "c1"} {
+		rolling.PushLeft(value)
+	}
+	for _, value := range []string{"a2", "b2"} {
+		rolling.PushRight(value)
+	}
+	if length := rolling.Length(); length != 1 {
+		t.Errorf("failed at forgotten matches, actual: %d, expected: %d", length, 1)
+	}
+}
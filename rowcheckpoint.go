@@ -0,0 +1,93 @@
+package golcs
+
+import "context"
+
+// WithRowCheckpoints makes IndexPairs() keep only every k-th row of the memo
+// table instead of the full table.
+//
+// The rows between two checkpoints are calculated again while backtracking,
+// so finding the pairs takes about twice the time of the full table, while
+// the memory usage goes down from O(mn) to O(m(n/k + k)) for a Left of size
+// m and a Right of size n; k around sqrt(n) gives the least memory. It is a
+// middle ground between the full table and Hirschberg's algorithm, which
+// needs only O(m+n) memory with a recursive divide and conquer. The results
+// are identical to those without the option. Table() and the methods which
+// depend on it still use the full table. k <= 0 disables the option.
+func WithRowCheckpoints(k int) Option {
+	return func(lcs *lcs) {
+		lcs.rowCheckpoints = k
+	}
+}
+
+func (lcs *lcs) rowCheckpointIndexPairsContext(ctx context.Context, k int) ([]IndexPair, error) {
+	sizeX := len(lcs.left) + 1
+	sizeY := len(lcs.right) + 1
+
+	// checkpoints[i] is the row of y = i*k in the memo table
+	checkpoints := make([][]int, (sizeY-1)/k+1)
+	checkpoints[0] = make([]int, sizeX)
+	prev, curr := make([]int, sizeX), make([]int, sizeX)
+	for y := 1; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		lcs.nextRow(prev, curr, y)
+		if y%k == 0 {
+			checkpoints[y/k] = append([]int(nil), curr...)
+		}
+		prev, curr = curr, prev
+	}
+
+	pairs := make([]IndexPair, prev[sizeX-1])
+	// rows between two checkpoints, reused for every block
+	buffer := make([][]int, min(k, sizeY-1)+1)
+	for i := 1; i < len(buffer); i++ {
+		buffer[i] = make([]int, sizeX)
+	}
+	x, y := len(lcs.left), len(lcs.right)
+	for block := len(checkpoints) - 1; x > 0 && y > 0; block-- {
+		// rebuild the rows from the checkpoint up to y
+		base := block * k
+		rows := buffer[:y-base+1]
+		rows[0] = checkpoints[block]
+		for i := 1; i < len(rows); i++ {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+				// nop
+			}
+			lcs.nextRow(rows[i-1], rows[i], base+i)
+		}
+
+		for x > 0 && y > base {
+			curr, prev := rows[y-base], rows[y-base-1]
+			if lcs.match(x-1, y-1) {
+				pairs[curr[x]-1] = IndexPair{Left: x - 1, Right: y - 1}
+				x--
+				y--
+			} else if curr[x-1] >= prev[x] {
+				x--
+			} else {
+				y--
+			}
+		}
+	}
+
+	return pairs, nil
+}
+
+// nextRow calculates the row of y in the memo table into curr from the row of y-1.
+func (lcs *lcs) nextRow(prev, curr []int, y int) {
+	curr[0] = 0
+	for x := 1; x < len(curr); x++ {
+		increment := 0
+		if lcs.match(x-1, y-1) {
+			increment = 1
+		}
+		curr[x] = max(prev[x-1]+increment, prev[x], curr[x-1])
+	}
+}
@@ -0,0 +1,51 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func randomInts(random *rand.Rand, size, kinds int) []interface{} {
+	values := make([]interface{}, size)
+	for i := range values {
+		values[i] = random.Intn(kinds)
+	}
+	return values
+}
+
+func TestWithRowCheckpoints(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		left := randomInts(random, random.Intn(40), 4)
+		right := randomInts(random, random.Intn(40), 4)
+		expected := New(left, right).IndexPairs()
+
+		for _, k := range []int{1, 2, 3, 7, 100} {
+			actual := New(left, right, WithRowCheckpoints(k)).IndexPairs()
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("test case %d failed with k = %d, actual: %v, expected: %v", i, k, actual, expected)
+			}
+		}
+	}
+}
+
+func benchmarkIndexPairs(b *testing.B, opts ...Option) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 1000, 10)
+	right := randomInts(random, 1000, 10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(left, right, opts...).IndexPairs()
+	}
+}
+
+func BenchmarkIndexPairs(b *testing.B) {
+	benchmarkIndexPairs(b)
+}
+
+func BenchmarkIndexPairsWithRowCheckpoints(b *testing.B) {
+	benchmarkIndexPairs(b, WithRowCheckpoints(32))
+}
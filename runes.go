@@ -0,0 +1,69 @@
+package golcs
+
+import "fmt"
+
+// NewRunes creates a new LCS calculator comparing two texts character by
+// character. The elements are the runes of the texts.
+func NewRunes(a, b string, opts ...Option) LCS {
+	return New(splitRunes(a), splitRunes(b), opts...)
+}
+
+func splitRunes(text string) []interface{} {
+	runes := []interface{}{}
+	for _, r := range text {
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// WithTabWidth makes DisplayColumns() advance a tab to the next multiple of n
+// columns, as editors show it, instead of a single column. It changes only the
+// columns for display: the elements are still matched by their literal
+// characters, so a tab never matches spaces. n <= 1 disables the option.
+func WithTabWidth(n int) Option {
+	return func(lcs *lcs) {
+		lcs.tabWidth = n
+	}
+}
+
+// DisplayColumns implements LCS.DisplayColumns()
+//
+// left[i] is the column where Left[i] starts when the elements are written one
+// after another, counted from 0, and left[len(Left)] is the column after the
+// last one, so left[i+1]-left[i] is the width of Left[i]; right is the same for
+// Right. Each rune takes one column, and an element other than a rune takes
+// the columns of its characters written with fmt.Sprint. A tab advances to the
+// next multiple of the width given by WithTabWidth(), and "\n" starts a new
+// line at column 0, so that the columns line up as in an editor.
+func (lcs *lcs) DisplayColumns() (left, right []int) {
+	return lcs.displayColumns(lcs.left), lcs.displayColumns(lcs.right)
+}
+
+func (lcs *lcs) displayColumns(values []interface{}) []int {
+	columns := make([]int, len(values)+1)
+	column := 0
+	for i, value := range values {
+		columns[i] = column
+		if r, ok := value.(rune); ok {
+			column = lcs.advanceColumn(column, r)
+			continue
+		}
+		for _, r := range fmt.Sprint(value) {
+			column = lcs.advanceColumn(column, r)
+		}
+	}
+	columns[len(values)] = column
+	return columns
+}
+
+// advanceColumn returns the column after r written at column.
+func (lcs *lcs) advanceColumn(column int, r rune) int {
+	switch {
+	case r == '\n':
+		return 0
+	case r == '\t' && lcs.tabWidth > 1:
+		return (column/lcs.tabWidth + 1) * lcs.tabWidth
+	default:
+		return column + 1
+	}
+}
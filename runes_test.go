@@ -0,0 +1,75 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRunes(t *testing.T) {
+	newLcs := NewRunes("héllo", "hallö")
+	if expected := []interface{}{'h', 'l', 'l'}; !reflect.DeepEqual(newLcs.Values(), expected) {
+		t.Errorf("actual: %v, expected: %v", newLcs.Values(), expected)
+	}
+}
+
+func TestWithTabWidth(t *testing.T) {
+	cases := []struct {
+		left     string
+		right    string
+		tabWidth int
+		columns  []int
+		length   int
+	}{
+		{
+			left:     "\tx",
+			right:    "    x",
+			tabWidth: 4,
+			columns:  []int{0, 4, 5},
+			length:   1,
+		},
+		{
+			left:     "ab\tc",
+			right:    "ab  c",
+			tabWidth: 4,
+			columns:  []int{0, 1, 2, 4, 5},
+			length:   3,
+		},
+		{
+			left:     "abcd\t\te",
+			right:    "abcd\te",
+			tabWidth: 4,
+			columns:  []int{0, 1, 2, 3, 4, 8, 12, 13},
+			length:   6,
+		},
+		{
+			left:     "a\tb\n\tc",
+			right:    "a\tb",
+			tabWidth: 8,
+			columns:  []int{0, 1, 8, 9, 0, 8, 9},
+			length:   3,
+		},
+		{
+			left:     "a\tb",
+			right:    "a b",
+			tabWidth: 0,
+			columns:  []int{0, 1, 2, 3},
+			length:   2,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := NewRunes(c.left, c.right, WithTabWidth(c.tabWidth))
+		left, _ := newLcs.DisplayColumns()
+		if !reflect.DeepEqual(left, c.columns) {
+			t.Errorf("test case %d failed at columns, actual: %v, expected: %v", i, left, c.columns)
+		}
+		if actual := newLcs.Length(); actual != c.length {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, c.length)
+		}
+	}
+
+	_, right := New([]interface{}{}, []interface{}{"ab", '\t', 12}, WithTabWidth(4)).DisplayColumns()
+	if expected := []int{0, 2, 4, 6}; !reflect.DeepEqual(right, expected) {
+		t.Errorf("failed at other elements, actual: %v, expected: %v", right, expected)
+	}
+}
@@ -0,0 +1,110 @@
+package golcs
+
+import "context"
+
+// LongestMonotonicRun implements LCS.LongestMonotonicRun()
+func (lcs *lcs) LongestMonotonicRun() []IndexPair {
+	run, _ := lcs.LongestMonotonicRunContext(context.Background())
+	return run
+}
+
+// LongestMonotonicRunContext implements LCS.LongestMonotonicRunContext()
+//
+// The run is the longest part of IndexPairs() in which each Right index is
+// the previous one plus one, so the matched elements are contiguous in Right.
+// The Left indices may still skip elements, unlike a run contiguous in both
+// arrays. The first one is returned when several runs have the same length.
+func (lcs *lcs) LongestMonotonicRunContext(ctx context.Context) ([]IndexPair, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bestStart, bestEnd := 0, 0
+	for start := 0; start < len(pairs); {
+		end := start + 1
+		for end < len(pairs) && pairs[end].Right == pairs[end-1].Right+1 {
+			end++
+		}
+		if end-start > bestEnd-bestStart {
+			bestStart, bestEnd = start, end
+		}
+		start = end
+	}
+	return pairs[bestStart:bestEnd], nil
+}
+
+// CopyBlock is a contiguous region of Left which appears in Right as is.
+type CopyBlock struct {
+	LeftStart  int
+	RightStart int
+	Length     int
+}
+
+// CopyBlocks implements LCS.CopyBlocks()
+func (lcs *lcs) CopyBlocks() []CopyBlock {
+	blocks, _ := lcs.CopyBlocksContext(context.Background())
+	return blocks
+}
+
+// CopyBlocksContext implements LCS.CopyBlocksContext()
+//
+// The blocks are the maximal runs of IndexPairs() in which both the Left and
+// the Right indices increase by one, in the order of the pairs. Copying them
+// from Left and inserting the other elements of Right in between reconstructs
+// Right, so they are the copy operations of a delta encoding.
+func (lcs *lcs) CopyBlocksContext(ctx context.Context) ([]CopyBlock, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []CopyBlock{}
+	for _, run := range contiguousRuns(pairs) {
+		blocks = append(blocks, CopyBlock{LeftStart: run[0].Left, RightStart: run[0].Right, Length: len(run)})
+	}
+	return blocks, nil
+}
+
+// contiguousRuns splits pairs into the maximal runs contiguous in both arrays.
+func contiguousRuns(pairs []IndexPair) [][]IndexPair {
+	runs := [][]IndexPair{}
+	for start := 0; start < len(pairs); {
+		end := start + 1
+		for end < len(pairs) && pairs[end].Left == pairs[end-1].Left+1 && pairs[end].Right == pairs[end-1].Right+1 {
+			end++
+		}
+		runs = append(runs, pairs[start:end])
+		start = end
+	}
+	return runs
+}
+
+// ValueRuns implements LCS.ValueRuns()
+func (lcs *lcs) ValueRuns() [][]interface{} {
+	runs, _ := lcs.ValueRunsContext(context.Background())
+	return runs
+}
+
+// ValueRunsContext implements LCS.ValueRunsContext()
+//
+// The values are split where the Left or the Right index of IndexPairs() is
+// not the previous one plus one, so each run is a block of elements common to
+// both arrays without any change in between, the same as CopyBlocks(). Joining
+// the runs gives Values().
+func (lcs *lcs) ValueRunsContext(ctx context.Context) ([][]interface{}, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := [][]interface{}{}
+	for _, run := range contiguousRuns(pairs) {
+		values := make([]interface{}, len(run))
+		for i, pair := range run {
+			values[i] = lcs.left[pair.Left]
+		}
+		runs = append(runs, values)
+	}
+	return runs, nil
+}
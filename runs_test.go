@@ -0,0 +1,110 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLongestMonotonicRun(t *testing.T) {
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+		run   []IndexPair
+	}{
+		{
+			left:  []interface{}{1, 2, 3, 4, 5, 6},
+			right: []interface{}{1, 2, 9, 3, 5, 6},
+			run:   []IndexPair{{2, 3}, {4, 4}, {5, 5}},
+		},
+		{
+			left:  []interface{}{1, 2, 3},
+			right: []interface{}{1, 0, 2, 0, 3},
+			run:   []IndexPair{{0, 0}},
+		},
+		{
+			left:  []interface{}{1, 0, 0, 2},
+			right: []interface{}{1, 2},
+			run:   []IndexPair{{0, 0}, {3, 1}},
+		},
+		{
+			left:  []interface{}{1},
+			right: []interface{}{2},
+			run:   []IndexPair{},
+		},
+	}
+
+	for i, c := range cases {
+		actual := New(c.left, c.right).LongestMonotonicRun()
+		if !reflect.DeepEqual(actual, c.run) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.run)
+		}
+	}
+}
+
+func TestCopyBlocks(t *testing.T) {
+	cases := []struct {
+		left   []interface{}
+		right  []interface{}
+		blocks []CopyBlock
+	}{
+		{
+			left:   []interface{}{1, 2, 3, 4, 5, 6, 7},
+			right:  []interface{}{1, 2, 9, 3, 4, 5, 7},
+			blocks: []CopyBlock{{0, 0, 2}, {2, 3, 3}, {6, 6, 1}},
+		},
+		{
+			left:   []interface{}{"a", "b", "c"},
+			right:  []interface{}{"x", "a", "b", "c", "y"},
+			blocks: []CopyBlock{{0, 1, 3}},
+		},
+		{
+			left:   []interface{}{1, 2},
+			right:  []interface{}{3},
+			blocks: []CopyBlock{},
+		},
+	}
+
+	for i, c := range cases {
+		actual := New(c.left, c.right).CopyBlocks()
+		if !reflect.DeepEqual(actual, c.blocks) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.blocks)
+		}
+	}
+}
+
+func TestValueRuns(t *testing.T) {
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+		runs  [][]interface{}
+	}{
+		{
+			left:  []interface{}{1, 2, 3, 4, 5, 6, 7},
+			right: []interface{}{1, 2, 9, 3, 4, 5, 7},
+			runs:  [][]interface{}{{1, 2}, {3, 4, 5}, {7}},
+		},
+		{
+			// a gap only in Left splits the runs too
+			left:  []interface{}{"a", "b", "x", "c"},
+			right: []interface{}{"a", "b", "c"},
+			runs:  [][]interface{}{{"a", "b"}, {"c"}},
+		},
+		{
+			left:  []interface{}{"a", "b", "c"},
+			right: []interface{}{"x", "a", "b", "c", "y"},
+			runs:  [][]interface{}{{"a", "b", "c"}},
+		},
+		{
+			left:  []interface{}{1, 2},
+			right: []interface{}{3},
+			runs:  [][]interface{}{},
+		},
+	}
+
+	for i, c := range cases {
+		actual := New(c.left, c.right).ValueRuns()
+		if !reflect.DeepEqual(actual, c.runs) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.runs)
+		}
+	}
+}
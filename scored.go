@@ -0,0 +1,49 @@
+package golcs
+
+import "context"
+
+// WithScoredMatch sets a function to compare elements which may match with a
+// penalty for their difference, such as two lines different only in spacing,
+// to align the near matches in between the exact LCS and fuzzy alignments.
+//
+// The function is called like the one of WithEqual(), and the elements it
+// says match are the matches of Table() and the other methods on the memo
+// table. IndexPairs() is instead the common subsequence of the highest
+// number of pairs minus their total penalty, that is, each match gains
+// 1 - penalty, so a match without a penalty counts as one of the LCS and one
+// with a penalty of 1 or more is never chosen. The pairs are found with the DP
+// of the LCS over the gains, and the same gains drive its backtracking: from
+// the end of the arrays, a match is taken when it is on a path of the highest
+// gain, otherwise an element of Left is skipped when that keeps the gain, and
+// an element of Right otherwise, as the memo table does. The function is
+// called once more for the penalty of each match the DP finds. IndexPairs()
+// and the methods on it may have fewer pairs than the LCS; Length() is their
+// number.
+func WithScoredMatch(score func(a, b interface{}) (matches bool, penalty float64)) Option {
+	return func(lcs *lcs) {
+		lcs.scoredMatch = score
+		lcs.equal = func(a, b interface{}) bool {
+			matches, _ := score(a, b)
+			return matches
+		}
+		lcs.customEqual = true
+	}
+}
+
+// scoredIndexPairsContext finds the pairs of the highest gain of WithScoredMatch().
+func (lcs *lcs) scoredIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	sizeX := len(lcs.left)
+	gains := make([]float64, sizeX*len(lcs.right))
+	scored := make([]bool, len(gains))
+	pairs, _, err := maxWeightIndexPairsContext(ctx, sizeX, len(lcs.right), func(x, y int) float64 {
+		if i := y*sizeX + x; !scored[i] {
+			scored[i] = true
+			if lcs.match(x, y) {
+				_, penalty := lcs.scoredMatch(lcs.leftKeys[x], lcs.rightKeys[y])
+				gains[i] = 1 - penalty
+			}
+		}
+		return gains[y*sizeX+x]
+	})
+	return pairs, err
+}
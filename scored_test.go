@@ -0,0 +1,104 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithScoredMatch(t *testing.T) {
+	// the same words match with a penalty of 0.1 per space of difference
+	spaced := func(a, b interface{}) (bool, float64) {
+		sa, sb := a.(string), b.(string)
+		if strings.TrimSpace(sa) != strings.TrimSpace(sb) {
+			return false, 0
+		}
+		return true, 0.1 * float64(abs(len(sa)-len(sb)))
+	}
+	// "a" and "b" match "a'" and "b'" with a penalty
+	primed := func(penalty float64) func(a, b interface{}) (bool, float64) {
+		return func(a, b interface{}) (bool, float64) {
+			if a == b {
+				return true, 0
+			}
+			if a.(string)+"'" == b.(string) {
+				return true, penalty
+			}
+			return false, 0
+		}
+	}
+
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		score    func(a, b interface{}) (bool, float64)
+		expected []IndexPair
+	}{
+		{
+			left:     []interface{}{},
+			right:    []interface{}{"a"},
+			score:    spaced,
+			expected: []IndexPair{},
+		},
+		// the exact match is chosen over the near one
+		{
+			left:     []interface{}{"a", "x"},
+			right:    []interface{}{"a", "a  ", "x"},
+			score:    spaced,
+			expected: []IndexPair{{0, 0}, {1, 2}},
+		},
+		{
+			left:     []interface{}{"a  ", "x"},
+			right:    []interface{}{"a  ", "a", "x"},
+			score:    spaced,
+			expected: []IndexPair{{0, 0}, {1, 2}},
+		},
+		// two near matches gain 1.2, more than an exact one
+		{
+			left:     []interface{}{"a", "b"},
+			right:    []interface{}{"b", "a'", "b'"},
+			score:    primed(0.4),
+			expected: []IndexPair{{0, 1}, {1, 2}},
+		},
+		// two near matches gain 0.8, less than an exact one
+		{
+			left:     []interface{}{"a", "b"},
+			right:    []interface{}{"b", "a'", "b'"},
+			score:    primed(0.6),
+			expected: []IndexPair{{1, 0}},
+		},
+		// a penalty of 1 is never worth it
+		{
+			left:     []interface{}{"a"},
+			right:    []interface{}{"a'"},
+			score:    primed(1),
+			expected: []IndexPair{},
+		},
+	}
+
+	for i, cs := range cases {
+		newLcs := New(cs.left, cs.right, WithScoredMatch(cs.score))
+		pairs := newLcs.IndexPairs()
+		if !reflect.DeepEqual(pairs, cs.expected) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, pairs, cs.expected)
+		}
+		if length := newLcs.Length(); length != len(cs.expected) {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, length, len(cs.expected))
+		}
+	}
+
+	// without penalties, it is the LCS
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(20), 4)
+		right := randomInts(random, random.Intn(20), 4)
+		pairs := New(left, right, WithScoredMatch(func(a, b interface{}) (bool, float64) {
+			return a == b, 0
+		})).IndexPairs()
+		checkCommonSubsequence(t, i, left, right, pairs)
+		if expected := New(left, right).Length(); len(pairs) != expected {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, len(pairs), expected)
+		}
+	}
+}
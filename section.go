@@ -0,0 +1,46 @@
+package golcs
+
+import "context"
+
+// DiffSection is a group of hunks separated from the others by a large
+// unchanged region, which a UI can collapse.
+type DiffSection struct {
+	// Gap is the number of unchanged elements between the previous section,
+	// or the start of the arrays, and the first hunk of the section.
+	Gap   int
+	Hunks []Hunk
+}
+
+// DiffSections implements LCS.DiffSections()
+func (lcs *lcs) DiffSections(contextSize, gapThreshold int) []DiffSection {
+	sections, _ := lcs.DiffSectionsContext(context.Background(), contextSize, gapThreshold)
+	return sections
+}
+
+// DiffSectionsContext implements LCS.DiffSectionsContext()
+//
+// The hunks are those of Hunks(contextSize). The unchanged elements between
+// two hunks are those in neither of them, which excludes their context. A new
+// section starts when there are gapThreshold unchanged elements or more
+// between a hunk and the previous one, so the hunks of a section are close to
+// each other. The first section starts at the first hunk, and its Gap is the
+// number of unchanged elements before it. There is no section without hunks.
+func (lcs *lcs) DiffSectionsContext(ctx context.Context, contextSize, gapThreshold int) ([]DiffSection, error) {
+	hunks, err := lcs.HunksContext(ctx, contextSize)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []DiffSection{}
+	end := 0
+	for _, hunk := range hunks {
+		gap := hunk.LeftStart - end
+		if len(sections) == 0 || gap >= gapThreshold {
+			sections = append(sections, DiffSection{Gap: gap})
+		}
+		last := &sections[len(sections)-1]
+		last.Hunks = append(last.Hunks, hunk)
+		end = hunk.LeftStart + hunk.LeftLength
+	}
+	return sections, nil
+}
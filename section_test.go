@@ -0,0 +1,49 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSections(t *testing.T) {
+	left := make([]interface{}, 100)
+	for i := range left {
+		left[i] = i
+	}
+	right := append([]interface{}(nil), left...)
+	// two clusters of changes far from each other
+	right[10], right[14] = "a", "b"
+	right[80] = "c"
+
+	cases := []struct {
+		gapThreshold int
+		gaps         []int
+		hunks        [][]int
+	}{
+		{gapThreshold: 20, gaps: []int{7, 59}, hunks: [][]int{{7}, {77}}},
+		{gapThreshold: 1000, gaps: []int{7}, hunks: [][]int{{7, 77}}},
+	}
+
+	for i, c := range cases {
+		sections := New(left, right).DiffSections(3, c.gapThreshold)
+		gaps, hunks := []int{}, [][]int{}
+		for _, section := range sections {
+			gaps = append(gaps, section.Gap)
+			starts := []int{}
+			for _, hunk := range section.Hunks {
+				starts = append(starts, hunk.LeftStart)
+			}
+			hunks = append(hunks, starts)
+		}
+		if !reflect.DeepEqual(gaps, c.gaps) {
+			t.Errorf("test case %d failed at gaps, actual: %v, expected: %v", i, gaps, c.gaps)
+		}
+		if !reflect.DeepEqual(hunks, c.hunks) {
+			t.Errorf("test case %d failed at hunks, actual: %v, expected: %v", i, hunks, c.hunks)
+		}
+	}
+
+	if sections := New(left, left).DiffSections(3, 10); len(sections) != 0 {
+		t.Errorf("failed at the same arrays, actual: %v", sections)
+	}
+}
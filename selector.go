@@ -0,0 +1,87 @@
+package golcs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidSelection is returned by the context aware methods when the
+// function given with WithMatchSelector() returns an index not in its candidates.
+var ErrInvalidSelection = errors.New("golcs: selected index is not a candidate")
+
+// WithMatchSelector lets the caller choose which element of Right an element
+// of Left is matched with when more than one would keep the LCS the longest,
+// such as the copies of a repeated line, for a deterministic alignment which
+// knows the domain.
+//
+// The backtracking walks Left from the end and matches each element of Left
+// which can be in the rest of an LCS. selector is called with the index of
+// the element of Left and the indices of Right it can be matched with, in
+// ascending order, only when there are two or more of them; a single
+// candidate is taken as is. selector must return one of the candidates, and
+// any other value makes IndexPairsContext() and the methods on it return
+// ErrInvalidSelection, and the methods without a context return empty
+// results. The option changes only which pairs are chosen: Length() is the
+// same. It needs the full memo table even with WithRowCheckpoints(), and is
+// ignored by WithMaxGap(), WithRarityWeighting(), WithBudget() and
+// WithMinimalDisplacement(), which choose the pairs in their own ways.
+func WithMatchSelector(selector func(leftIdx int, candidates []int) int) Option {
+	return func(lcs *lcs) {
+		lcs.matchSelector = selector
+	}
+}
+
+// selectorIndexPairsContext backtracks the memo table with the choices of
+// WithMatchSelector(). Right[j] is a candidate for Left[x-1] when they match
+// and table[x-1][j] is one less than the length left to find, with j before
+// the elements of Right already matched.
+func (lcs *lcs) selectorIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	table, err := lcs.TableContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]IndexPair, table[len(table)-1][len(table[0])-1])
+	candidates := []int{}
+	for x, y := len(lcs.left), len(lcs.right); x > 0 && y > 0; x-- {
+		length := table[x][y]
+		if length == 0 {
+			break
+		}
+		// table[x-1][j] is non-decreasing in j, so the candidates end where it drops
+		candidates = candidates[:0]
+		for j := y - 1; j >= 0 && table[x-1][j] >= length-1; j-- {
+			if table[x-1][j] == length-1 && lcs.match(x-1, j) {
+				candidates = append(candidates, j)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		for i, k := 0, len(candidates)-1; i < k; i, k = i+1, k-1 {
+			candidates[i], candidates[k] = candidates[k], candidates[i]
+		}
+
+		selected := candidates[0]
+		if len(candidates) > 1 {
+			selected = lcs.matchSelector(x-1, append([]int(nil), candidates...))
+			if !containsInt(candidates, selected) {
+				return nil, ErrInvalidSelection
+			}
+		}
+		pairs[length-1] = IndexPair{Left: x - 1, Right: selected}
+		y = selected
+	}
+
+	return pairs, nil
+}
+
+// containsInt reports whether values has value.
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
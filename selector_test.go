@@ -0,0 +1,84 @@
+package golcs
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithMatchSelector(t *testing.T) {
+	first := func(leftIdx int, candidates []int) int { return candidates[0] }
+	last := func(leftIdx int, candidates []int) int { return candidates[len(candidates)-1] }
+
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		selector func(int, []int) int
+		expected []IndexPair
+	}{
+		{
+			left:     []interface{}{"x"},
+			right:    []interface{}{"x", "y", "x", "x"},
+			selector: first,
+			expected: []IndexPair{{0, 0}},
+		},
+		{
+			left:     []interface{}{"x"},
+			right:    []interface{}{"x", "y", "x", "x"},
+			selector: func(leftIdx int, candidates []int) int { return candidates[1] },
+			expected: []IndexPair{{0, 2}},
+		},
+		{
+			left:     []interface{}{"a", "x", "b"},
+			right:    []interface{}{"a", "x", "x", "b"},
+			selector: first,
+			expected: []IndexPair{{0, 0}, {1, 1}, {2, 3}},
+		},
+		{
+			left:     []interface{}{"a", "x", "b"},
+			right:    []interface{}{"a", "x", "x", "b"},
+			selector: last,
+			expected: []IndexPair{{0, 0}, {1, 2}, {2, 3}},
+		},
+		{
+			left:     []interface{}{"x", "x"},
+			right:    []interface{}{"x", "x", "x"},
+			selector: first,
+			expected: []IndexPair{{0, 0}, {1, 1}},
+		},
+	}
+
+	for i, cs := range cases {
+		pairs := New(cs.left, cs.right, WithMatchSelector(cs.selector)).IndexPairs()
+		if !reflect.DeepEqual(pairs, cs.expected) {
+			t.Errorf("test case %d failed at index pairs, actual: %v, expected: %v", i, pairs, cs.expected)
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(20), 3)
+		right := randomInts(random, random.Intn(20), 3)
+		selector := func(leftIdx int, candidates []int) int {
+			if len(candidates) < 2 {
+				t.Errorf("test case %d failed at candidates, actual: %v", i, candidates)
+			}
+			return candidates[random.Intn(len(candidates))]
+		}
+		pairs := New(left, right, WithMatchSelector(selector)).IndexPairs()
+		if expected := New(left, right).Length(); len(pairs) != expected {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, len(pairs), expected)
+		}
+		checkCommonSubsequence(t, i, left, right, pairs)
+	}
+}
+
+func TestWithMatchSelectorInvalid(t *testing.T) {
+	newLcs := New([]interface{}{"x"}, []interface{}{"x", "x"}, WithMatchSelector(func(leftIdx int, candidates []int) int {
+		return -1
+	}))
+	if pairs, err := newLcs.IndexPairsContext(context.Background()); err != ErrInvalidSelection {
+		t.Errorf("actual: %v, %v, expected: %v", pairs, err, ErrInvalidSelection)
+	}
+}
@@ -0,0 +1,122 @@
+package golcs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// ErrInvalidSpans is returned by ApplySpans when spans do not match the document.
+var ErrInvalidSpans = errors.New("golcs: spans do not match the document")
+
+// SpanKind represents the kind of a Span.
+type SpanKind int
+
+const (
+	// SpanRetain keeps Length elements from Start.
+	SpanRetain SpanKind = iota
+	// SpanInsert inserts Values at Start.
+	SpanInsert
+	// SpanDelete removes Length elements from Start.
+	SpanDelete
+)
+
+// Span is a change of a range of a document, as rich-text editors apply them.
+type Span struct {
+	Kind SpanKind
+	// Start is the position in the document with the spans before applied.
+	Start int
+	// Length is the number of the elements retained, inserted or deleted.
+	Length int
+	// Values are the inserted elements for SpanInsert, the deleted ones for
+	// SpanDelete and nil for SpanRetain.
+	Values []interface{}
+}
+
+// Spans implements LCS.Spans()
+func (lcs *lcs) Spans() []Span {
+	spans, _ := lcs.SpansContext(context.Background())
+	return spans
+}
+
+// SpansContext implements LCS.SpansContext()
+//
+// The spans are Operations() with the ranges they change. The document starts
+// as Left and evolves as the spans are applied one after another, and Start
+// of a span is its position in the document as it is at that span: a retain
+// or an insert moves the position after its elements, and a delete does not
+// move it, as the elements after it shift back. So Start of each span is the
+// sum of the lengths of the retains and the inserts before it, and the
+// position of the elements of Right in the document after the spans. Unlike
+// the indices of Edit, which are of the original arrays, the positions can
+// be given to a document model as they are, keeping the attributes of the
+// retained elements. The deleted elements are in the spans as well, to check
+// them against the document.
+func (lcs *lcs) SpansContext(ctx context.Context) ([]Span, error) {
+	operations, err := lcs.OperationsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spans := make([]Span, len(operations))
+	position, x := 0, 0
+	for i, operation := range operations {
+		span := Span{Start: position, Length: operation.Count}
+		switch operation.Kind {
+		case OperationRetain:
+			span.Kind = SpanRetain
+			position += operation.Count
+			x += operation.Count
+		case OperationInsert:
+			span.Kind = SpanInsert
+			span.Values = operation.Values
+			position += operation.Count
+		case OperationDelete:
+			span.Kind = SpanDelete
+			span.Values = append([]interface{}{}, lcs.left[x:x+operation.Count]...)
+			x += operation.Count
+		}
+		spans[i] = span
+	}
+	return spans, nil
+}
+
+// ApplySpans applies spans such as Spans() to a document. It returns
+// ErrInvalidSpans when a span does not start at the position of the spans
+// before it, the retains and the deletes do not cover document exactly, a
+// Length differs from the Values of an insert or the Values of a delete
+// differ from the document with reflect.DeepEqual; a delete without Values is
+// not checked.
+func ApplySpans(document []interface{}, spans []Span) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(document))
+	x := 0
+	for _, span := range spans {
+		if span.Start != len(result) || span.Length < 0 {
+			return nil, ErrInvalidSpans
+		}
+		switch span.Kind {
+		case SpanRetain:
+			if x+span.Length > len(document) {
+				return nil, ErrInvalidSpans
+			}
+			result = append(result, document[x:x+span.Length]...)
+			x += span.Length
+		case SpanInsert:
+			if span.Length != len(span.Values) {
+				return nil, ErrInvalidSpans
+			}
+			result = append(result, span.Values...)
+		case SpanDelete:
+			if x+span.Length > len(document) || (span.Values != nil && !reflect.DeepEqual(document[x:x+span.Length], span.Values)) {
+				return nil, ErrInvalidSpans
+			}
+			x += span.Length
+		default:
+			return nil, ErrInvalidSpans
+		}
+	}
+	if x != len(document) {
+		return nil, ErrInvalidSpans
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSpans(t *testing.T) {
+	left := []interface{}{"a", "b", "c", "d", "e"}
+	right := []interface{}{"a", "x", "y", "d", "e", "f"}
+
+	expected := []Span{
+		{Kind: SpanRetain, Start: 0, Length: 1},
+		{Kind: SpanDelete, Start: 1, Length: 2, Values: []interface{}{"b", "c"}},
+		{Kind: SpanInsert, Start: 1, Length: 2, Values: []interface{}{"x", "y"}},
+		{Kind: SpanRetain, Start: 3, Length: 2},
+		{Kind: SpanInsert, Start: 5, Length: 1, Values: []interface{}{"f"}},
+	}
+	newLcs := New(left, right)
+	spans := newLcs.Spans()
+	if !reflect.DeepEqual(spans, expected) {
+		t.Errorf("actual: %v, expected: %v", spans, expected)
+	}
+
+	// the values are copies, which the caller may change
+	spans[1].Values[0], spans[2].Values[0] = "changed", "changed"
+	if left[1] != "b" || right[1] != "x" {
+		t.Errorf("failed at inputs, actual: %v, %v", left, right)
+	}
+	if actual := newLcs.Spans(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("failed after a change, actual: %v, expected: %v", actual, expected)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(20), 4)
+		right := randomInts(random, random.Intn(20), 4)
+		spans := New(left, right).Spans()
+
+		applied, err := ApplySpans(left, spans)
+		if err != nil || !reflect.DeepEqual(applied, right) {
+			t.Errorf("test case %d failed at apply, actual: %v, %v, expected: %v", i, applied, err, right)
+		}
+	}
+}
+
+func TestApplySpansInvalid(t *testing.T) {
+	document := []interface{}{1, 2, 3}
+	cases := [][]Span{
+		{{Kind: SpanRetain, Start: 0, Length: 2}},
+		{{Kind: SpanRetain, Start: 0, Length: 4}},
+		{{Kind: SpanRetain, Start: 1, Length: 3}},
+		{{Kind: SpanRetain, Start: 0, Length: 3}, {Kind: SpanInsert, Start: 3, Length: 2, Values: []interface{}{4}}},
+		{{Kind: SpanDelete, Start: 0, Length: 1, Values: []interface{}{9}}, {Kind: SpanRetain, Start: 0, Length: 2}},
+		{{Kind: SpanKind(9), Start: 0, Length: 3}},
+	}
+	for i, spans := range cases {
+		if _, err := ApplySpans(document, spans); err != ErrInvalidSpans {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, err, ErrInvalidSpans)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package golcs
+
+import (
+	"context"
+	"sort"
+)
+
+// WithHuntSzymanski finds IndexPairs() with the Hunt–Szymanski algorithm,
+// which only visits the pairs of elements which match instead of the whole
+// memo table, so it is fast when few elements of Left and Right match, like
+// the lines of two versions of a source file.
+//
+// The positions of each value in Left are indexed once, and for each element
+// of Right in order, the positions of Left with the same value update the
+// smallest end of a common subsequence of each length, which takes
+// O((r + n) log n) time for r matching pairs and O(r) memory. With Prepare(),
+// the index is built by Prepare() and shared by every Diff(). The pairs may
+// differ from those of the memo table, but the length is the same. Only
+// booleans, numbers and strings are indexed by their values, and the other
+// elements are compared with every element of Left which is not one of them.
+// WithEqual() and the other options changing the equality make every pair
+// a candidate, which loses the sparsity; the memo table is used in that case.
+func WithHuntSzymanski() Option {
+	return func(lcs *lcs) {
+		lcs.huntSzymanski = true
+	}
+}
+
+// positionIndex is the positions of the elements of Left by their values.
+// It is never modified after newPositionIndex, so it can be shared.
+type positionIndex struct {
+	// ids identify the basic values of Left like those of PreparedLeft
+	ids map[interface{}]int
+	// positions[id] are the ascending positions of the value of id in Left
+	positions [][]int
+	// others are the positions of the elements which are not basic values
+	others []int
+}
+
+// newPositionIndex indexes the keys of Left with the IDs given by Prepare.
+func newPositionIndex(ids map[interface{}]int, leftIDs []int) *positionIndex {
+	index := &positionIndex{ids: ids, positions: make([][]int, len(ids))}
+	for x, id := range leftIDs {
+		if id == preparedOther {
+			index.others = append(index.others, x)
+		} else {
+			index.positions[id] = append(index.positions[id], x)
+		}
+	}
+	return index
+}
+
+// leftIDs gives IDs to the keys of Left as Prepare does.
+func leftIDs(keys []interface{}) (map[interface{}]int, []int) {
+	ids := map[interface{}]int{}
+	leftIDs := make([]int, len(keys))
+	for i, key := range keys {
+		if !isBasicValue(key) {
+			leftIDs[i] = preparedOther
+			continue
+		}
+		id, ok := ids[key]
+		if !ok {
+			id = len(ids)
+			ids[key] = id
+		}
+		leftIDs[i] = id
+	}
+	return ids, leftIDs
+}
+
+// candidates returns the positions of Left which may match a key of Right.
+func (index *positionIndex) candidates(key interface{}) []int {
+	if !isBasicValue(key) {
+		return index.others
+	}
+	if id, ok := index.ids[key]; ok {
+		return index.positions[id]
+	}
+	return nil
+}
+
+// huntSzymanskiIndexPairsContext finds the pairs with the Hunt–Szymanski
+// algorithm: thresholds[k] is the smallest x where a common subsequence of
+// length k+1 of Left[:x+1] and the rows so far ends, and links[k] is the
+// last match of that subsequence.
+func (lcs *lcs) huntSzymanskiIndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	if lcs.customEqual {
+		pairs, _, err := lcs.tableIndexPairsContext(ctx, -1)
+		return pairs, err
+	}
+	index := lcs.positionIndex
+	if index == nil {
+		index = newPositionIndex(leftIDs(lcs.leftKeys))
+	}
+
+	type link struct {
+		pair     IndexPair
+		previous *link
+	}
+	thresholds := []int{}
+	links := []*link{}
+	for y, key := range lcs.rightKeys {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		candidates := index.candidates(key)
+		// from the end, so that a row never extends its own matches
+		for i := len(candidates) - 1; i >= 0; i-- {
+			x := candidates[i]
+			if !lcs.match(x, y) {
+				continue
+			}
+			k := sort.SearchInts(thresholds, x)
+			if k < len(thresholds) && thresholds[k] == x {
+				continue
+			}
+			var previous *link
+			if k > 0 {
+				previous = links[k-1]
+			}
+			current := &link{pair: IndexPair{Left: x, Right: y}, previous: previous}
+			if k == len(thresholds) {
+				thresholds = append(thresholds, x)
+				links = append(links, current)
+			} else {
+				thresholds[k] = x
+				links[k] = current
+			}
+		}
+	}
+
+	pairs := make([]IndexPair, len(links))
+	if len(links) > 0 {
+		for i, current := len(links)-1, links[len(links)-1]; current != nil; i, current = i-1, current.previous {
+			pairs[i] = current.pair
+		}
+	}
+	return pairs, nil
+}
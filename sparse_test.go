@@ -0,0 +1,92 @@
+package golcs
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestWithHuntSzymanski(t *testing.T) {
+	type point struct{ x, y int }
+	cases := []struct {
+		left  []interface{}
+		right []interface{}
+	}{
+		{[]interface{}{}, []interface{}{}},
+		{[]interface{}{1, 2, 3}, []interface{}{}},
+		{[]interface{}{"a", "b", "c", "b", "d", "a", "b"}, []interface{}{"b", "d", "c", "a", "b", "a"}},
+		// the other elements are compared with each other
+		{[]interface{}{point{1, 2}, 1, point{3, 4}}, []interface{}{point{3, 4}, 1, point{1, 2}, point{3, 4}}},
+	}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		cases = append(cases, struct {
+			left  []interface{}
+			right []interface{}
+		}{randomInts(random, random.Intn(30), 6), randomInts(random, random.Intn(30), 6)})
+	}
+
+	for i, cs := range cases {
+		excluded := []int{}
+		if len(cs.left) > 0 {
+			excluded = []int{0}
+		}
+		for _, variant := range []struct {
+			lcs      LCS
+			expected LCS
+		}{
+			{New(cs.left, cs.right, WithHuntSzymanski()), New(cs.left, cs.right)},
+			{Prepare(cs.left, WithHuntSzymanski()).Diff(cs.right), New(cs.left, cs.right)},
+			{New(cs.left, cs.right, WithHuntSzymanski(), WithExcludedLeft(excluded)), New(cs.left, cs.right, WithExcludedLeft(excluded))},
+		} {
+			pairs := variant.lcs.IndexPairs()
+			checkCommonSubsequence(t, i, cs.left, cs.right, pairs)
+			if expected := variant.expected.Length(); len(pairs) != expected {
+				t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, len(pairs), expected)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := New([]interface{}{1}, []interface{}{1}, WithHuntSzymanski()).IndexPairsContext(ctx); err != context.Canceled {
+		t.Errorf("failed at cancel, actual: %v, expected: %v", err, context.Canceled)
+	}
+}
+
+func benchmarkOneToManySparse(b *testing.B, prepare func(left []interface{}) func(right []interface{}) LCS) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 2000, 1000)
+	rights := make([][]interface{}, 10)
+	for i := range rights {
+		rights[i] = randomInts(random, 2000, 1000)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diff := prepare(left)
+		for _, right := range rights {
+			diff(right).IndexPairs()
+		}
+	}
+}
+
+func BenchmarkOneToManySparseTable(b *testing.B) {
+	benchmarkOneToManySparse(b, func(left []interface{}) func(right []interface{}) LCS {
+		return Prepare(left).Diff
+	})
+}
+
+func BenchmarkOneToManySparseNew(b *testing.B) {
+	benchmarkOneToManySparse(b, func(left []interface{}) func(right []interface{}) LCS {
+		return func(right []interface{}) LCS {
+			return New(left, right, WithHuntSzymanski())
+		}
+	})
+}
+
+func BenchmarkOneToManySparsePrepare(b *testing.B) {
+	benchmarkOneToManySparse(b, func(left []interface{}) func(right []interface{}) LCS {
+		return Prepare(left, WithHuntSzymanski()).Diff
+	})
+}
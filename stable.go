@@ -0,0 +1,46 @@
+package golcs
+
+import "context"
+
+// StableDiff implements LCS.StableDiff()
+func (lcs *lcs) StableDiff() []Edit {
+	edits, _ := lcs.StableDiffContext(context.Background())
+	return edits
+}
+
+// StableDiffContext implements LCS.StableDiffContext()
+//
+// The script is a minimal one canonicalized so that it depends only on the
+// elements of Left and Right and the equality, never on how the ties of the
+// engines break: walking from the start of the arrays with SuffixTable(), an
+// element of Left is matched with the element of Right at the same point
+// whenever an LCS goes through the pair, otherwise it is deleted whenever an
+// LCS still remains without it, and only otherwise an element of Right is
+// inserted. Every match is thus shifted as far to the start of the arrays as
+// it goes, and between two matches the deletions come before the insertions
+// as in EditScript(). As the same arrays always give the same script,
+// diffing Left and Apply(Left, StableDiff()) again gives the same script, so
+// a stored diff never churns. The options choosing the pairs in their own
+// way, like WithPatience(), are not used: the script always keeps an LCS.
+func (lcs *lcs) StableDiffContext(ctx context.Context) ([]Edit, error) {
+	table, err := lcs.SuffixTableContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]IndexPair, 0, table[0][0])
+	for x, y := 0, 0; table[x][y] > 0; {
+		if lcs.match(x, y) && table[x+1][y+1] == table[x][y]-1 {
+			pairs = append(pairs, IndexPair{Left: x, Right: y})
+			x++
+			y++
+		} else if table[x+1][y] == table[x][y] {
+			x++
+		} else {
+			y++
+		}
+	}
+
+	edits, _ := lcs.editScript(pairs, len(lcs.left)+len(lcs.right)-len(pairs))
+	return edits, nil
+}
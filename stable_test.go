@@ -0,0 +1,75 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestStableDiff(t *testing.T) {
+	cases := []struct {
+		left     []interface{}
+		right    []interface{}
+		expected []Edit
+	}{
+		{
+			// the match is shifted to the start
+			left:  []interface{}{"a", "b", "a"},
+			right: []interface{}{"a"},
+			expected: []Edit{
+				{Kind: EditEqual, Left: 0, Right: 0, Value: "a"},
+				{Kind: EditDelete, Left: 1, Right: -1, Value: "b"},
+				{Kind: EditDelete, Left: 2, Right: -1, Value: "a"},
+			},
+		},
+		{
+			left:  []interface{}{"a"},
+			right: []interface{}{"b", "a", "a"},
+			expected: []Edit{
+				{Kind: EditInsert, Left: -1, Right: 0, Value: "b"},
+				{Kind: EditEqual, Left: 0, Right: 1, Value: "a"},
+				{Kind: EditInsert, Left: -1, Right: 2, Value: "a"},
+			},
+		},
+		{
+			// deleting is preferred to inserting
+			left:  []interface{}{"a", "b"},
+			right: []interface{}{"b", "a"},
+			expected: []Edit{
+				{Kind: EditDelete, Left: 0, Right: -1, Value: "a"},
+				{Kind: EditEqual, Left: 1, Right: 0, Value: "b"},
+				{Kind: EditInsert, Left: -1, Right: 1, Value: "a"},
+			},
+		},
+		{
+			left:     []interface{}{},
+			right:    []interface{}{},
+			expected: []Edit{},
+		},
+	}
+	for i, c := range cases {
+		if actual := New(c.left, c.right).StableDiff(); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.expected)
+		}
+	}
+}
+
+func TestStableDiffRediff(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+
+		edits := New(left, right, WithPatience()).StableDiff()
+		if expected := New(left, right).Length(); len(edits) != len(left)+len(right)-expected {
+			t.Errorf("test case %d failed at minimal, actual: %v edits", i, len(edits))
+		}
+		applied, err := Apply(left, edits)
+		if err != nil || !reflect.DeepEqual(applied, right) {
+			t.Fatalf("test case %d failed at apply, error: %v", i, err)
+		}
+		if actual := New(left, applied).StableDiff(); !reflect.DeepEqual(actual, edits) {
+			t.Errorf("test case %d failed at rediff, actual: %v, expected: %v", i, actual, edits)
+		}
+	}
+}
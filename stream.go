@@ -0,0 +1,48 @@
+package golcs
+
+import "context"
+
+// DiffStream implements LCS.DiffStream()
+//
+// The edits of EditScriptContext() are sent to the first channel one by one,
+// and the channel is closed after the last one or on an error. The second
+// channel receives the error, ctx.Err() when ctx is canceled, if any, and
+// is closed after the first one.
+//
+// The memo table and its backtracking, which every option of this package
+// uses, find the edits from the end of the arrays, so the whole script is
+// calculated before the first edit is sent. Only the sending is incremental:
+// a slow consumer does not need to hold the script, and a cancellation stops
+// the sending before the next edit.
+func (lcs *lcs) DiffStream(ctx context.Context) (<-chan Edit, <-chan error) {
+	edits := make(chan Edit)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(edits)
+
+		script, err := lcs.EditScriptContext(ctx)
+		if err != nil {
+			errs <- err
+			return
+		}
+		for _, edit := range script {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			default:
+				// nop
+			}
+			select {
+			case edits <- edit:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return edits, errs
+}
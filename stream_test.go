@@ -0,0 +1,50 @@
+package golcs
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDiffStream(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	left := randomInts(random, 50, 5)
+	right := randomInts(random, 50, 5)
+	newLcs := New(left, right)
+
+	edits, errs := newLcs.DiffStream(context.Background())
+	actual := []Edit{}
+	for edit := range edits {
+		actual = append(actual, edit)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+	if expected := newLcs.EditScript(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %v, expected: %v", actual, expected)
+	}
+}
+
+func TestDiffStreamCancel(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	newLcs := New(randomInts(random, 50, 5), randomInts(random, 50, 5))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	edits, errs := newLcs.DiffStream(ctx)
+	for i := 0; i < 3; i++ {
+		<-edits
+	}
+	cancel()
+
+	received := 0
+	for range edits {
+		received++
+	}
+	if received > 1 {
+		t.Errorf("too many edits after cancel: %d", received)
+	}
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
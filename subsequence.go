@@ -0,0 +1,66 @@
+package golcs
+
+import "context"
+
+// IsInsertOnly implements LCS.IsInsertOnly()
+func (lcs *lcs) IsInsertOnly() bool {
+	insertOnly, _ := lcs.IsInsertOnlyContext(context.Background())
+	return insertOnly
+}
+
+// IsInsertOnlyContext implements LCS.IsInsertOnlyContext()
+//
+// Right is Left with elements inserted and none deleted when Left is a
+// subsequence of Right, that is, Length() == len(Left), like an append-only
+// log or a fast-forward. Instead of the memo table, it walks Right once and
+// matches each element of Left with the first element of Right which matches
+// after the previous one, which takes len(Right) comparisons at most. An
+// empty Left is always insert only.
+func (lcs *lcs) IsInsertOnlyContext(ctx context.Context) (bool, error) {
+	return lcs.isSubsequenceContext(ctx, len(lcs.left), len(lcs.right), lcs.match)
+}
+
+// IsDeleteOnly implements LCS.IsDeleteOnly()
+func (lcs *lcs) IsDeleteOnly() bool {
+	deleteOnly, _ := lcs.IsDeleteOnlyContext(context.Background())
+	return deleteOnly
+}
+
+// IsDeleteOnlyContext implements LCS.IsDeleteOnlyContext()
+//
+// Right is Left with elements deleted and none inserted when Right is a
+// subsequence of Left, that is, Length() == len(Right). It is checked like
+// IsInsertOnly() with the arrays swapped. An empty Right is always delete only.
+func (lcs *lcs) IsDeleteOnlyContext(ctx context.Context) (bool, error) {
+	return lcs.isSubsequenceContext(ctx, len(lcs.right), len(lcs.left), func(i, j int) bool {
+		return lcs.match(j, i)
+	})
+}
+
+// isSubsequenceContext reports whether the m elements are a subsequence of
+// the n elements, where match(i, j) compares the i-th of the m elements and
+// the j-th of the n elements. Taking the first match for each element is
+// never worse than a later one, which leaves fewer elements for the rest.
+func (lcs *lcs) isSubsequenceContext(ctx context.Context, m, n int, match func(i, j int) bool) (bool, error) {
+	if err := lcs.compareAll(ctx); err != nil {
+		return false, err
+	}
+
+	i := 0
+	for j := 0; i < m && j < n && m-i <= n-j; j++ {
+		select { // check in each j to save some time
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+			// nop
+		}
+		if match(i, j) {
+			i++
+		}
+	}
+
+	if err := lcs.failed(); err != nil {
+		return false, err
+	}
+	return i == m, nil
+}
@@ -0,0 +1,46 @@
+package golcs
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIsInsertOnly(t *testing.T) {
+	cases := []struct {
+		left       []interface{}
+		right      []interface{}
+		insertOnly bool
+		deleteOnly bool
+	}{
+		{[]interface{}{}, []interface{}{}, true, true},
+		{[]interface{}{1, 2, 3}, []interface{}{1, 2, 3}, true, true},
+		{[]interface{}{1, 2, 3}, []interface{}{1, 2, 3, 4, 5}, true, false},
+		{[]interface{}{1, 2, 3}, []interface{}{0, 1, 4, 2, 3}, true, false},
+		{[]interface{}{1, 2, 3, 4, 5}, []interface{}{2, 4}, false, true},
+		{[]interface{}{}, []interface{}{1}, true, false},
+		{[]interface{}{1, 2, 3}, []interface{}{1, 3, 4}, false, false},
+		{[]interface{}{1, 2}, []interface{}{2, 1}, false, false},
+		{[]interface{}{1, 1}, []interface{}{1}, false, true},
+	}
+
+	for i, cs := range cases {
+		newLcs := New(cs.left, cs.right)
+		if actual := newLcs.IsInsertOnly(); actual != cs.insertOnly {
+			t.Errorf("test case %d failed at insert only, actual: %v, expected: %v", i, actual, cs.insertOnly)
+		}
+		if actual := newLcs.IsDeleteOnly(); actual != cs.deleteOnly {
+			t.Errorf("test case %d failed at delete only, actual: %v, expected: %v", i, actual, cs.deleteOnly)
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(8), 2)
+		right := randomInts(random, random.Intn(8), 2)
+		newLcs := New(left, right)
+		length := New(left, right).Length()
+		if newLcs.IsInsertOnly() != (length == len(left)) || newLcs.IsDeleteOnly() != (length == len(right)) {
+			t.Errorf("test case %d failed, left: %v, right: %v", i, left, right)
+		}
+	}
+}
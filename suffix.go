@@ -0,0 +1,148 @@
+package golcs
+
+import "context"
+
+// SuffixTable implements LCS.SuffixTable()
+func (lcs *lcs) SuffixTable() [][]int {
+	table, _ := lcs.SuffixTableContext(context.Background())
+	return table
+}
+
+// SuffixTableContext implements LCS.SuffixTableContext()
+//
+// The table is calculated from the end of the arrays: table[x][y] is the LCS
+// length of Left[x:] and Right[y:], so table[0][0] is Length() and the last
+// row and column are zeros. Table()[x][y] + SuffixTable()[x][y] is the best
+// length of an LCS split at x in Left and y in Right, which is what
+// Hirschberg's algorithm uses to find the split points. It is cached
+// separately from Table().
+func (lcs *lcs) SuffixTableContext(ctx context.Context) ([][]int, error) {
+	if lcs.suffixTable != nil {
+		return lcs.suffixTable, nil
+	}
+	if err := lcs.compareAll(ctx); err != nil {
+		return nil, err
+	}
+
+	sizeX := len(lcs.left) + 1
+	sizeY := len(lcs.right) + 1
+
+	table := make([][]int, sizeX)
+	for x := 0; x < sizeX; x++ {
+		table[x] = make([]int, sizeY)
+	}
+
+	for y := sizeY - 2; y >= 0; y-- {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// nop
+		}
+		if err := lcs.failed(); err != nil {
+			return nil, err
+		}
+		for x := sizeX - 2; x >= 0; x-- {
+			increment := 0
+			if lcs.match(x, y) {
+				increment = 1
+			}
+			table[x][y] = max(table[x+1][y+1]+increment, table[x+1][y], table[x][y+1])
+		}
+	}
+
+	if err := lcs.failed(); err != nil {
+		return nil, err
+	}
+	lcs.suffixTable = table
+	return table, nil
+}
+
+// SensitivityByIndex implements LCS.SensitivityByIndex()
+func (lcs *lcs) SensitivityByIndex() []int {
+	deltas, _ := lcs.SensitivityByIndexContext(context.Background())
+	return deltas
+}
+
+// SensitivityByIndexContext implements LCS.SensitivityByIndexContext()
+//
+// deltas[i] is the change of the LCS length when lcs.Left()[i] is removed,
+// which is -1 for an element in every LCS and 0 for the others. Instead of
+// calculating the LCS again for each element, it combines the memo table
+// and the table from the end of the arrays: without the i-th element, the
+// length is the maximum of Table()[i][j] + the length of Left[i+1:] and
+// Right[j:] over every j. It takes O(mn) time and memory like Table().
+func (lcs *lcs) SensitivityByIndexContext(ctx context.Context) ([]int, error) {
+	prefix, err := lcs.TableContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := lcs.SuffixTableContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	length := prefix[len(lcs.left)][len(lcs.right)]
+	deltas := make([]int, len(lcs.left))
+	for i := range deltas {
+		best := 0
+		for j := 0; j <= len(lcs.right); j++ {
+			best = max(best, prefix[i][j]+suffix[i+1][j])
+		}
+		deltas[i] = best - length
+	}
+	return deltas, nil
+}
+
+// SecondBestLength implements LCS.SecondBestLength()
+func (lcs *lcs) SecondBestLength() int {
+	length, _ := lcs.SecondBestLengthContext(context.Background())
+	return length
+}
+
+// SecondBestLengthContext implements LCS.SecondBestLengthContext()
+//
+// The second best length is the length of the longest common subsequence
+// which has at least one pair not in IndexPairs(), so it differs from the
+// chosen alignment in a match rather than only leaving some of them out. It
+// is Length() when another LCS exists, and the further below Length() it is,
+// the more robust the chosen alignment is; it is 0 when no element other than
+// the chosen ones match. Like SensitivityByIndex(), it combines the memo table
+// and the table from the end of the arrays instead of calculating the LCS
+// again: the longest subsequence through the match of x and y is
+// Table()[x][y] + 1 + SuffixTable()[x+1][y+1], and the maximum is taken over
+// every match not chosen. It takes O(mn) time and memory like Table().
+func (lcs *lcs) SecondBestLengthContext(ctx context.Context) (int, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	prefix, err := lcs.TableContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	suffix, err := lcs.SuffixTableContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	chosen := make(map[IndexPair]bool, len(pairs))
+	for _, pair := range pairs {
+		chosen[pair] = true
+	}
+	best := 0
+	for y := 0; y < len(lcs.right); y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+			// nop
+		}
+		for x := 0; x < len(lcs.left); x++ {
+			if !chosen[IndexPair{Left: x, Right: y}] && lcs.match(x, y) {
+				best = max(best, prefix[x][y]+1+suffix[x+1][y+1])
+			}
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,107 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSensitivityByIndex(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		left := randomInts(random, random.Intn(15), 3)
+		right := randomInts(random, random.Intn(15), 3)
+		length := New(left, right).Length()
+
+		// brute force leave-one-out
+		expected := make([]int, len(left))
+		for j := range left {
+			removed := append(append([]interface{}{}, left[:j]...), left[j+1:]...)
+			expected[j] = New(removed, right).Length() - length
+		}
+
+		if actual := New(left, right).SensitivityByIndex(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, expected)
+		}
+	}
+}
+
+func TestSuffixTable(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		left := randomInts(random, random.Intn(15), 3)
+		right := randomInts(random, random.Intn(15), 3)
+		newLcs := New(left, right)
+		suffix := newLcs.SuffixTable()
+
+		if suffix[0][0] != newLcs.Length() {
+			t.Errorf("test case %d failed at length, actual: %d, expected: %d", i, suffix[0][0], newLcs.Length())
+		}
+		for x := 0; x <= len(left); x++ {
+			for y := 0; y <= len(right); y++ {
+				if expected := New(left[x:], right[y:]).Length(); suffix[x][y] != expected {
+					t.Errorf("test case %d failed at (%d, %d), actual: %d, expected: %d", i, x, y, suffix[x][y], expected)
+				}
+			}
+		}
+	}
+}
+
+func TestSecondBestLength(t *testing.T) {
+	cases := []struct {
+		left       []interface{}
+		right      []interface{}
+		length     int
+		secondBest int
+	}{
+		{
+			// the only alignment
+			left:       []interface{}{1, 2, 3, 4},
+			right:      []interface{}{1, 2, 3, 4},
+			length:     4,
+			secondBest: 0,
+		},
+		{
+			// a robust alignment with a far stray match
+			left:       []interface{}{1, 2, 3, 4, 5},
+			right:      []interface{}{1, 2, 3, 4, 5, 1},
+			length:     5,
+			secondBest: 1,
+		},
+		{
+			// either 1 of Left can be matched
+			left:       []interface{}{1, 2, 1},
+			right:      []interface{}{1},
+			length:     1,
+			secondBest: 1,
+		},
+		{
+			left:       []interface{}{"a", "b", "c", "b", "d"},
+			right:      []interface{}{"a", "b", "d"},
+			length:     3,
+			secondBest: 3,
+		},
+		{
+			left:       []interface{}{1, 2, 3},
+			right:      []interface{}{3, 2, 1},
+			length:     1,
+			secondBest: 1,
+		},
+		{
+			left:       []interface{}{},
+			right:      []interface{}{1},
+			length:     0,
+			secondBest: 0,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := New(c.left, c.right)
+		if actual := newLcs.Length(); actual != c.length {
+			t.Errorf("test case %d failed at length, actual: %v, expected: %v", i, actual, c.length)
+		}
+		if actual := newLcs.SecondBestLength(); actual != c.secondBest {
+			t.Errorf("test case %d failed at second best length, actual: %v, expected: %v", i, actual, c.secondBest)
+		}
+	}
+}
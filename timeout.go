@@ -0,0 +1,81 @@
+package golcs
+
+import "time"
+
+// WithComparisonTimeout gives each comparison of two elements a time budget.
+//
+// Each comparison runs in its own goroutine, and one which does not finish
+// within timeout is regarded as "not the same" and onTimeout, if not nil, is
+// called with the indices in Left and Right, for example to collect them as
+// errors. A comparison which timed out cannot be stopped, so its goroutine
+// keeps running in the background until the equality returns. At most 64
+// goroutines of comparisons run at the same time, including those which
+// timed out, and a comparison waits for one of them to return within its own
+// timeout, so equalities hanging for good never pile up more goroutines.
+//
+// Whether a comparison times out depends on the load of the machine, so the
+// results may differ from one run to another. Within an LCS calculator, the
+// outcome of each comparison is remembered, at two bits for every pair of
+// elements, so that all the methods agree with each other, even when they
+// compare from several goroutines.
+func WithComparisonTimeout(timeout time.Duration, onTimeout func(left, right int)) Option {
+	return func(lcs *lcs) {
+		lcs.comparisonTimeout = timeout
+		lcs.onTimeout = onTimeout
+		lcs.comparisonSlots = make(chan struct{}, maxTimedComparisons)
+	}
+}
+
+// maxTimedComparisons is the most goroutines of WithComparisonTimeout()
+// running at the same time.
+const maxTimedComparisons = 64
+
+func (lcs *lcs) matchWithTimeout(x, y int) bool {
+	cell := x*len(lcs.right) + y
+	word, bit := cell/64, uint64(1)<<(cell%64)
+	lcs.failureLock.Lock()
+	if lcs.decided == nil {
+		words := (len(lcs.left)*len(lcs.right) + 63) / 64
+		lcs.decided, lcs.decisions = make([]uint64, words), make([]uint64, words)
+	}
+	if lcs.decided[word]&bit != 0 {
+		same := lcs.decisions[word]&bit != 0
+		lcs.failureLock.Unlock()
+		return same
+	}
+	lcs.failureLock.Unlock()
+
+	timer := time.NewTimer(lcs.comparisonTimeout)
+	defer timer.Stop()
+	same, finished := false, false
+	select {
+	case lcs.comparisonSlots <- struct{}{}:
+		result := make(chan bool, 1)
+		left, right := lcs.leftKeys[x], lcs.rightKeys[y]
+		go func() {
+			defer func() { <-lcs.comparisonSlots }()
+			result <- lcs.equal(left, right)
+		}()
+		select {
+		case same = <-result:
+			finished = true
+		case <-timer.C:
+		}
+	case <-timer.C:
+	}
+	if !finished && lcs.onTimeout != nil {
+		lcs.onTimeout(x, y)
+	}
+
+	lcs.failureLock.Lock()
+	defer lcs.failureLock.Unlock()
+	if lcs.decided[word]&bit != 0 {
+		// decided by another goroutine in the meantime
+		return lcs.decisions[word]&bit != 0
+	}
+	lcs.decided[word] |= bit
+	if same {
+		lcs.decisions[word] |= bit
+	}
+	return same
+}
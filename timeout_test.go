@@ -0,0 +1,94 @@
+package golcs
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithComparisonTimeout(t *testing.T) {
+	slowEqual := func(a, b interface{}) bool {
+		if a == "slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		return a == b
+	}
+
+	timedOut := []IndexPair{}
+	newLcs := New(
+		[]interface{}{"a", "slow", "b"},
+		[]interface{}{"a", "slow", "b"},
+		WithEqual(slowEqual),
+		WithComparisonTimeout(5*time.Millisecond, func(left, right int) {
+			timedOut = append(timedOut, IndexPair{Left: left, Right: right})
+		}),
+	)
+
+	expectedPairs := []IndexPair{{0, 0}, {2, 2}}
+	if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, expectedPairs) {
+		t.Errorf("failed at index pairs, actual: %v, expected: %v", actual, expectedPairs)
+	}
+	if actual := newLcs.Length(); actual != 2 {
+		t.Errorf("failed at length, actual: %d", actual)
+	}
+
+	// each slow comparison times out only once
+	expectedTimedOut := []IndexPair{{1, 0}, {1, 1}, {1, 2}}
+	if !reflect.DeepEqual(timedOut, expectedTimedOut) {
+		t.Errorf("failed at timed out comparisons, actual: %v, expected: %v", timedOut, expectedTimedOut)
+	}
+
+	generous := New(
+		[]interface{}{"a", "slow", "b"},
+		[]interface{}{"a", "slow", "b"},
+		WithEqual(slowEqual),
+		WithComparisonTimeout(time.Second, nil),
+	)
+	if actual := generous.Length(); actual != 3 {
+		t.Errorf("failed at length with a generous timeout, actual: %d", actual)
+	}
+}
+
+func TestWithComparisonTimeoutBounded(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var lock sync.Mutex
+	running, most := 0, 0
+	hangingEqual := func(a, b interface{}) bool {
+		lock.Lock()
+		running++
+		most = max(most, running)
+		lock.Unlock()
+		<-release
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return true
+	}
+
+	left := make([]interface{}, 20)
+	right := make([]interface{}, 10)
+	newLcs := New(left, right, WithEqual(hangingEqual), WithComparisonTimeout(time.Millisecond, nil)).(*lcs)
+
+	// compare from several goroutines at the same time
+	var group sync.WaitGroup
+	for y := range right {
+		group.Add(1)
+		go func(y int) {
+			defer group.Done()
+			for x := range left {
+				if newLcs.match(x, y) {
+					t.Errorf("failed at %d, %d, a hanging comparison matched", x, y)
+				}
+			}
+		}(y)
+	}
+	group.Wait()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if most > maxTimedComparisons {
+		t.Errorf("failed at goroutines, actual: %d, expected at most: %d", most, maxTimedComparisons)
+	}
+}
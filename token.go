@@ -0,0 +1,81 @@
+package golcs
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenizerFunc splits source code into tokens to be compared by NewCodeTokens.
+// Every returned element is compared with the others by equality, so a
+// tokenizer usually returns Token values carrying both the type and the text
+// of each token.
+type TokenizerFunc func(src string) []interface{}
+
+// TokenType classifies a Token produced by DefaultTokenizer.
+type TokenType int
+
+const (
+	// TokenIdentifier is a run of letters, digits and underscores starting with a non-digit.
+	TokenIdentifier TokenType = iota
+	// TokenNumber is a run of letters, digits, underscores and dots starting with a digit.
+	TokenNumber
+	// TokenBracket is one of the bracket characters ()[]{}.
+	TokenBracket
+	// TokenPunct is any other single non-space character.
+	TokenPunct
+)
+
+// Token is a single token of source code.
+type Token struct {
+	Type TokenType
+	Text string
+}
+
+// NewCodeTokens creates a new LCS calculator from two pieces of source code
+// split into tokens by lang. DefaultTokenizer is used when lang is nil.
+func NewCodeTokens(a, b string, lang TokenizerFunc) LCS {
+	if lang == nil {
+		lang = DefaultTokenizer
+	}
+	return New(lang(a), lang(b))
+}
+
+// DefaultTokenizer is a language agnostic TokenizerFunc. It drops whitespace
+// and returns identifiers, numbers, brackets and other punctuation as Token
+// values. Each bracket is a token on its own so that the nesting of blocks is
+// matched token by token.
+func DefaultTokenizer(src string) []interface{} {
+	tokens := []interface{}{}
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case isIdentRune(r):
+			tokenType := TokenIdentifier
+			if unicode.IsDigit(r) {
+				tokenType = TokenNumber
+			}
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if !isIdentRune(r) && !(tokenType == TokenNumber && r == '.') {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, Token{Type: tokenType, Text: src[start:i]})
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == '{' || r == '}':
+			tokens = append(tokens, Token{Type: TokenBracket, Text: src[i : i+size]})
+			i += size
+		default:
+			tokens = append(tokens, Token{Type: TokenPunct, Text: src[i : i+size]})
+			i += size
+		}
+	}
+	return tokens
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
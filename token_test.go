@@ -0,0 +1,69 @@
+package golcs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultTokenizer(t *testing.T) {
+	actual := DefaultTokenizer("if (x1 >= 10.5) { foo_bar() }")
+	expected := []interface{}{
+		Token{TokenIdentifier, "if"},
+		Token{TokenBracket, "("},
+		Token{TokenIdentifier, "x1"},
+		Token{TokenPunct, ">"},
+		Token{TokenPunct, "="},
+		Token{TokenNumber, "10.5"},
+		Token{TokenBracket, ")"},
+		Token{TokenBracket, "{"},
+		Token{TokenIdentifier, "foo_bar"},
+		Token{TokenBracket, "("},
+		Token{TokenBracket, ")"},
+		Token{TokenBracket, "}"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual: %#v, expected: %#v", actual, expected)
+	}
+}
+
+func TestNewCodeTokens(t *testing.T) {
+	fields := func(src string) []interface{} {
+		tokens := []interface{}{}
+		for _, field := range strings.Fields(src) {
+			tokens = append(tokens, field)
+		}
+		return tokens
+	}
+
+	cases := []struct {
+		left   string
+		right  string
+		lang   TokenizerFunc
+		values []interface{}
+	}{
+		{
+			left:   "a = b + c",
+			right:  "a  =  b\n+ d",
+			lang:   fields,
+			values: []interface{}{"a", "=", "b", "+"},
+		},
+		{
+			left:  "f(x)",
+			right: "f( y )",
+			lang:  nil,
+			values: []interface{}{
+				Token{TokenIdentifier, "f"},
+				Token{TokenBracket, "("},
+				Token{TokenBracket, ")"},
+			},
+		},
+	}
+
+	for i, c := range cases {
+		actualValues := NewCodeTokens(c.left, c.right, c.lang).Values()
+		if !reflect.DeepEqual(actualValues, c.values) {
+			t.Errorf("test case %d failed at values, actual: %#v, expected: %#v", i, actualValues, c.values)
+		}
+	}
+}
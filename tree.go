@@ -0,0 +1,23 @@
+package golcs
+
+// TreeWalker walks a tree in-order and calls visit for each node.
+// The values given to visit are the elements compared by NewTrees.
+type TreeWalker func(visit func(node interface{}))
+
+// NewTrees creates a new LCS calculator from the in-order traversals of two trees.
+//
+// The trees are compared as flat sequences only: two trees with the same
+// in-order traversal but different shapes have identical results. The indices
+// in IndexPairs() are positions in the traversals, which are available from
+// Left() and Right().
+func NewTrees(left, right TreeWalker) LCS {
+	return New(flatten(left), flatten(right))
+}
+
+func flatten(walk TreeWalker) []interface{} {
+	nodes := []interface{}{}
+	walk(func(node interface{}) {
+		nodes = append(nodes, node)
+	})
+	return nodes
+}
@@ -0,0 +1,51 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testTree struct {
+	left  *testTree
+	value string
+	right *testTree
+}
+
+func (tree *testTree) walk(visit func(node interface{})) {
+	if tree == nil {
+		return
+	}
+	tree.left.walk(visit)
+	visit(tree.value)
+	tree.right.walk(visit)
+}
+
+func TestNewTrees(t *testing.T) {
+	//     b           d
+	//    / \         / \
+	//   a   d       b   e
+	//      / \     /
+	//     c   e   a
+	left := &testTree{
+		left:  &testTree{value: "a"},
+		value: "b",
+		right: &testTree{left: &testTree{value: "c"}, value: "d", right: &testTree{value: "e"}},
+	}
+	right := &testTree{
+		left:  &testTree{left: &testTree{value: "a"}, value: "b"},
+		value: "d",
+		right: &testTree{value: "e"},
+	}
+
+	newLcs := NewTrees(left.walk, right.walk)
+
+	expectedLeft := []interface{}{"a", "b", "c", "d", "e"}
+	if !reflect.DeepEqual(newLcs.Left(), expectedLeft) {
+		t.Errorf("failed at left, actual: %#v, expected: %#v", newLcs.Left(), expectedLeft)
+	}
+
+	expectedPairs := []IndexPair{{0, 0}, {1, 1}, {3, 2}, {4, 3}}
+	if !reflect.DeepEqual(newLcs.IndexPairs(), expectedPairs) {
+		t.Errorf("failed at index pairs, actual: %#v, expected: %#v", newLcs.IndexPairs(), expectedPairs)
+	}
+}
@@ -0,0 +1,158 @@
+package golcs
+
+import "context"
+
+// WeightedLCS is the interface to calculate the maximum weight common
+// subsequence of two arrays created by NewWeighted.
+type WeightedLCS interface {
+	// IndexPairs calculates the pairs of indices of the maximum weight common subsequence.
+	IndexPairs() []IndexPair
+	// IndexPairsContext is a context aware version of IndexPairs()
+	IndexPairsContext(ctx context.Context) ([]IndexPair, error)
+	// Values calculates the elements of Left in IndexPairs().
+	Values() []interface{}
+	// ValuesContext is a context aware version of Values()
+	ValuesContext(ctx context.Context) ([]interface{}, error)
+	// Weight calculates the total weight of IndexPairs().
+	Weight() float64
+	// WeightContext is a context aware version of Weight()
+	WeightContext(ctx context.Context) (float64, error)
+	// Left returns one of the two arrays to be compared.
+	Left() []interface{}
+	// Right returns the other of the two arrays to be compared.
+	Right() []interface{}
+}
+
+type weightedLCS struct {
+	left   []interface{}
+	right  []interface{}
+	weight func(i, j int) float64
+	/* for caching */
+	indexPairs  []IndexPair
+	totalWeight float64
+}
+
+// NewWeighted creates a new calculator of the common subsequence of the
+// highest total weight, where weight(i, j) is the gain of matching left[i]
+// with right[j]. A weight of 0 or less means that the two are never matched,
+// so with a weight of 1 for the same elements it is the LCS.
+//
+// The pairs are found with the DP of the LCS over the weights: score[x][y] is
+// the highest total weight of left[:x] and right[:y], the maximum of
+// score[x-1][y], score[x][y-1] and score[x-1][y-1] + weight(x-1, y-1), and
+// the pairs are strictly increasing in both indices. It takes O(mn) time and
+// memory with weight called once for each pair of elements, and once more for
+// each pair on the backtracking path.
+func NewWeighted(left, right []interface{}, weight func(i, j int) float64) WeightedLCS {
+	return &weightedLCS{left: left, right: right, weight: weight}
+}
+
+// IndexPairs implements WeightedLCS.IndexPairs()
+func (lcs *weightedLCS) IndexPairs() []IndexPair {
+	pairs, _ := lcs.IndexPairsContext(context.Background())
+	return pairs
+}
+
+// IndexPairsContext implements WeightedLCS.IndexPairsContext()
+func (lcs *weightedLCS) IndexPairsContext(ctx context.Context) ([]IndexPair, error) {
+	if lcs.indexPairs != nil {
+		return lcs.indexPairs, nil
+	}
+
+	pairs, weight, err := maxWeightIndexPairsContext(ctx, len(lcs.left), len(lcs.right), lcs.weight)
+	if err != nil {
+		return nil, err
+	}
+	lcs.indexPairs, lcs.totalWeight = pairs, weight
+	return pairs, nil
+}
+
+// Values implements WeightedLCS.Values()
+func (lcs *weightedLCS) Values() []interface{} {
+	values, _ := lcs.ValuesContext(context.Background())
+	return values
+}
+
+// ValuesContext implements WeightedLCS.ValuesContext()
+func (lcs *weightedLCS) ValuesContext(ctx context.Context) ([]interface{}, error) {
+	pairs, err := lcs.IndexPairsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(pairs))
+	for i, pair := range pairs {
+		values[i] = lcs.left[pair.Left]
+	}
+	return values, nil
+}
+
+// Weight implements WeightedLCS.Weight()
+func (lcs *weightedLCS) Weight() float64 {
+	weight, _ := lcs.WeightContext(context.Background())
+	return weight
+}
+
+// WeightContext implements WeightedLCS.WeightContext()
+func (lcs *weightedLCS) WeightContext(ctx context.Context) (float64, error) {
+	if _, err := lcs.IndexPairsContext(ctx); err != nil {
+		return 0, err
+	}
+	return lcs.totalWeight, nil
+}
+
+// Left implements WeightedLCS.Left()
+func (lcs *weightedLCS) Left() []interface{} {
+	return lcs.left
+}
+
+// Right implements WeightedLCS.Right()
+func (lcs *weightedLCS) Right() []interface{} {
+	return lcs.right
+}
+
+// maxWeightIndexPairsContext finds the increasing pairs of the highest total
+// weight for arrays of the size m and n, where weight(i, j) <= 0 means that
+// the i-th and j-th elements never match.
+func maxWeightIndexPairsContext(ctx context.Context, m, n int, weight func(i, j int) float64) ([]IndexPair, float64, error) {
+	sizeX, sizeY := m+1, n+1
+	table := make([][]float64, sizeX)
+	for x := 0; x < sizeX; x++ {
+		table[x] = make([]float64, sizeY)
+	}
+	for y := 1; y < sizeY; y++ {
+		select { // check in each y to save some time
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		default:
+			// nop
+		}
+		for x := 1; x < sizeX; x++ {
+			score := table[x-1][y]
+			if table[x][y-1] > score {
+				score = table[x][y-1]
+			}
+			if w := weight(x-1, y-1); w > 0 && table[x-1][y-1]+w > score {
+				score = table[x-1][y-1] + w
+			}
+			table[x][y] = score
+		}
+	}
+
+	pairs := []IndexPair{}
+	for x, y := sizeX-1, sizeY-1; x > 0 && y > 0; {
+		if w := weight(x-1, y-1); w > 0 && table[x][y] == table[x-1][y-1]+w {
+			pairs = append(pairs, IndexPair{Left: x - 1, Right: y - 1})
+			x--
+			y--
+		} else if table[x-1][y] >= table[x][y-1] {
+			x--
+		} else {
+			y--
+		}
+	}
+	for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+	return pairs, table[sizeX-1][sizeY-1], nil
+}
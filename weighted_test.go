@@ -0,0 +1,92 @@
+package golcs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestNewWeighted(t *testing.T) {
+	left := []interface{}{"a", "b", "c"}
+	right := []interface{}{"c", "a", "b"}
+	same := func(i, j int) float64 {
+		if left[i] == right[j] {
+			return 1
+		}
+		return 0
+	}
+
+	cases := []struct {
+		weight func(i, j int) float64
+		pairs  []IndexPair
+		total  float64
+	}{
+		{
+			weight: same,
+			pairs:  []IndexPair{{Left: 0, Right: 1}, {Left: 1, Right: 2}},
+			total:  2,
+		},
+		{
+			// matching c is worth more than a and b together
+			weight: func(i, j int) float64 {
+				if left[i] == "c" {
+					return 5 * same(i, j)
+				}
+				return same(i, j)
+			},
+			pairs: []IndexPair{{Left: 2, Right: 0}},
+			total: 5,
+		},
+		{
+			// partial credit for similar elements
+			weight: func(i, j int) float64 {
+				if i == 0 && j == 0 {
+					return 0.5
+				}
+				return same(i, j)
+			},
+			pairs: []IndexPair{{Left: 0, Right: 1}, {Left: 1, Right: 2}},
+			total: 2,
+		},
+		{
+			weight: func(i, j int) float64 { return -1 },
+			pairs:  []IndexPair{},
+			total:  0,
+		},
+	}
+
+	for i, c := range cases {
+		newLcs := NewWeighted(left, right, c.weight)
+		if actual := newLcs.IndexPairs(); !reflect.DeepEqual(actual, c.pairs) {
+			t.Errorf("test case %d failed at pairs, actual: %v, expected: %v", i, actual, c.pairs)
+		}
+		if actual := newLcs.Weight(); actual != c.total {
+			t.Errorf("test case %d failed at weight, actual: %v, expected: %v", i, actual, c.total)
+		}
+	}
+
+	values := NewWeighted(left, right, same).Values()
+	if expected := []interface{}{"a", "b"}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("failed at values, actual: %v, expected: %v", values, expected)
+	}
+}
+
+func TestNewWeightedRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		left := randomInts(random, random.Intn(30), 4)
+		right := randomInts(random, random.Intn(30), 4)
+		weighted := NewWeighted(left, right, func(i, j int) float64 {
+			if reflect.DeepEqual(left[i], right[j]) {
+				return 1
+			}
+			return 0
+		})
+
+		pairs := weighted.IndexPairs()
+		checkCommonSubsequence(t, i, left, right, pairs)
+		if expected := New(left, right).Length(); len(pairs) != expected || weighted.Weight() != float64(expected) {
+			t.Errorf("test case %d failed, actual: %v, %v, expected: %v", i, len(pairs), weighted.Weight(), expected)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package golcs
+
+import "context"
+
+// WindowedLengths implements LCS.WindowedLengths()
+func (lcs *lcs) WindowedLengths(window, step int) []int {
+	lengths, _ := lcs.WindowedLengthsContext(context.Background(), window, step)
+	return lengths
+}
+
+// WindowedLengthsContext implements LCS.WindowedLengthsContext()
+//
+// lengths[i] is the LCS length of the whole Left and Right[i*step:i*step+window].
+// The windows start at 0, step, 2*step and so on, and only the windows which
+// fit in Right are used, so the last elements of Right are not in any window
+// when len(Right)-window is not a multiple of step. When Right is shorter
+// than window, there is one window of the whole Right. window and step less
+// than 1 are treated as 1. The elements of Left are prepared once, and each
+// window takes O(len(Left)*window) time with O(window) memory.
+func (lcs *lcs) WindowedLengthsContext(ctx context.Context, window, step int) ([]int, error) {
+	if err := lcs.compareAll(ctx); err != nil {
+		return nil, err
+	}
+	window, step = min(max(window, 1), len(lcs.right)), max(step, 1)
+
+	lengths := []int{}
+	for start := 0; start+window <= len(lcs.right); start += step {
+		length, err := lengthContext(ctx, len(lcs.left), window, func(i, j int) bool {
+			return lcs.match(i, start+j)
+		})
+		if err == nil {
+			err = lcs.failed()
+		}
+		if err != nil {
+			return nil, err
+		}
+		lengths = append(lengths, length)
+		if window == 0 {
+			break
+		}
+	}
+	return lengths, nil
+}
@@ -0,0 +1,34 @@
+package golcs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWindowedLengths(t *testing.T) {
+	left := []interface{}{1, 2, 3}
+	right := []interface{}{0, 1, 2, 3, 0, 0, 3, 2, 1}
+
+	cases := []struct {
+		window  int
+		step    int
+		lengths []int
+	}{
+		{window: 3, step: 1, lengths: []int{2, 3, 2, 1, 1, 1, 1}},
+		{window: 3, step: 3, lengths: []int{2, 1, 1}},
+		{window: 4, step: 3, lengths: []int{3, 1}},
+		{window: 20, step: 1, lengths: []int{3}},
+		{window: 0, step: 0, lengths: []int{0, 1, 1, 1, 0, 0, 1, 1, 1}},
+	}
+
+	for i, c := range cases {
+		actual := New(left, right).WindowedLengths(c.window, c.step)
+		if !reflect.DeepEqual(actual, c.lengths) {
+			t.Errorf("test case %d failed, actual: %v, expected: %v", i, actual, c.lengths)
+		}
+	}
+
+	if actual := New(left, []interface{}{}).WindowedLengths(3, 1); !reflect.DeepEqual(actual, []int{0}) {
+		t.Errorf("failed with empty right, actual: %v", actual)
+	}
+}